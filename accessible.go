@@ -0,0 +1,106 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// announce writes the current menu in Accessible mode, only ever appending plain lines: the numbered list when the
+// menu is entered (or drawn afresh after an option), otherwise a line naming the new selection and any new notice
+func (m *MenuTree) announce() {
+	state := m.state(m.currentMenu)
+	if m.spokenMenu != m.currentMenu || state.lastRenderLines == 0 {
+		fmt.Fprint(m.out, StripANSI(strings.TrimSuffix(m.lineFrame(), m.Strings.Choice)))
+		m.spokenMenu, m.spokenIndex, m.spokenNotice = m.currentMenu, state.selection, m.noticeLine()
+		state.lastRenderLines = 1
+		m.debug("announce", "menu", m.currentMenu.name, "entries", len(state.lineEntries))
+		return
+	}
+	if state.selection != m.spokenIndex {
+		m.spokenIndex = state.selection
+		fmt.Fprintln(m.out, m.selectionLine())
+	}
+	if notice := m.noticeLine(); notice != m.spokenNotice {
+		m.spokenNotice = notice
+		if notice != "" {
+			fmt.Fprintln(m.out, StripANSI(notice))
+		}
+	}
+}
+
+// selectionLine returns the line naming the selected entry and its position among the numbered entries (the back
+// and exit navigation rows counting after them)
+func (m *MenuTree) selectionLine() string {
+	state := m.state(m.currentMenu)
+	index, rows := state.selection, m.navigationRows()
+	position, label, row := 0, "", m.navigationRow(index)
+	for i, entry := range state.lineEntries {
+		if entry == index {
+			position = i + 1
+		}
+	}
+	switch {
+	case row == "BACK":
+		label = fmt.Sprintf(m.Strings.BackTo, m.previousMenu.name)
+	case row == "EXIT":
+		label = m.ExitLabel
+	case index >= 0 && index < len(m.currentMenu.optionsOrder):
+		name := m.currentMenu.optionsOrder[index]
+		label = sanitize(evaluate(name, m.currentMenu.options[name].labelFunc))
+	default:
+		if smm := m.subMenuMap[m.currentMenu]; index-len(m.currentMenu.optionsOrder) < len(smm) {
+			label = sanitize(smm[index-len(m.currentMenu.optionsOrder)].name)
+		}
+	}
+	for i, r := range rows {
+		if r == row {
+			position = len(state.lineEntries) + i + 1
+		}
+	}
+	return fmt.Sprintf(m.Strings.Selected, StripANSI(label), position, len(state.lineEntries)+len(rows))
+}
+
+// typedNumber collects the digits typed in Accessible mode (echoed after the choice prompt) and chooses the numbered
+// entry on Enter like line mode (0 goes back); it reports whether the key was used up
+func (m *MenuTree) typedNumber(input string) bool {
+	if input == "TOGGLE" {
+		return true //redraw stays off
+	}
+	if len(input) == 1 && input[0] >= '0' && input[0] <= '9' {
+		if m.typed == "" {
+			fmt.Fprint(m.out, m.Strings.Choice)
+		}
+		m.typed += input
+		fmt.Fprint(m.out, input)
+		return true
+	}
+	if m.typed == "" {
+		return false
+	}
+	typed := m.typed
+	m.typed = ""
+	fmt.Fprintln(m.out)
+	if input != "ENTER" {
+		return false
+	}
+	state := m.state(m.currentMenu)
+	switch n, _ := strconv.Atoi(typed); {
+	case n == 0:
+		m.handleKey("BACK")
+	case n <= len(state.lineEntries):
+		state.selection = state.lineEntries[n-1]
+		m.handleKey("ENTER")
+	default:
+		fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.InvalidChoice, typed))
+	}
+	return true
+}
+
+// hideCursor hides the cursor while the menu waits for keys, except in Accessible mode where screen readers and
+// braille terminals follow it
+func (m *MenuTree) hideCursor() {
+	if !m.Accessible {
+		fmt.Fprint(m.out, "\033[?25l")
+	}
+}
@@ -0,0 +1,152 @@
+package gomenutree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+type (
+	// Arg describes a handler parameter the menu prompts for before running an option
+	Arg struct {
+		Name        string             //shown in the prompt
+		Default     string             //used when nothing is typed
+		DefaultFunc func() string      //optional, generates the default when prompting (e.g. the current value), overriding Default
+		Validate    func(string) error //optional check on the typed text, a non-nil error asks again
+	}
+
+	// argSpec holds an option's handler with the parameters to prompt for
+	argSpec struct {
+		handler reflect.Value
+		args    []Arg
+	}
+)
+
+// durationType is checked before the parameter kind, as time.Duration is an int64
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// AddArgOption will add an option whose handler takes typed parameters (string, bool, int, uint and float kinds or
+// time.Duration) and optionally returns an error; when chosen, the menu prompts for each parameter in turn (described
+// by args, in order) and passes the converted values to the handler, asking again if a value does not convert or
+// validate (the option is not run after three failed attempts), e.g.
+// AddArgOption("ping", func(host string, count int) error {...}, Arg{Name: "host"}, Arg{Name: "count", Default: "3"})
+func (m *Menu) AddArgOption(name string, handler interface{}, args ...Arg) error {
+	h := reflect.ValueOf(handler)
+	if !h.IsValid() || h.Kind() != reflect.Func {
+		return fmt.Errorf("gomenutree: handler for %s is not a function", name)
+	}
+	if h.IsNil() {
+		return fmt.Errorf("gomenutree: handler for %s is nil", name)
+	}
+	t := h.Type()
+	if t.NumOut() > 1 || (t.NumOut() == 1 && t.Out(0) != reflect.TypeOf((*error)(nil)).Elem()) {
+		return fmt.Errorf("gomenutree: handler for %s may only return an error", name)
+	}
+	spec := &argSpec{handler: h}
+	for i := 0; i < t.NumIn(); i++ {
+		if _, e := parseArg("", t.In(i)); errors.Is(e, errUnsupportedArg) {
+			return fmt.Errorf("gomenutree: handler for %s: parameter %d: %w", name, i+1, e)
+		}
+		arg := Arg{Name: fmt.Sprintf("arg %d", i+1)}
+		if i < len(args) {
+			arg = args[i]
+		}
+		spec.args = append(spec.args, arg)
+	}
+	m.addOption(name, &option{
+		function: func() {},
+		args:     spec,
+	})
+	return nil
+}
+
+// defaultText returns the text used when nothing is typed
+func (a Arg) defaultText() string {
+	return evaluate(a.Default, a.DefaultFunc)
+}
+
+// errUnsupportedArg reports a handler parameter type that can not be prompted for
+var errUnsupportedArg = errors.New("unsupported parameter type")
+
+// parseArg converts typed text into a value of the parameter type
+func parseArg(text string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t == durationType {
+		d, e := time.ParseDuration(text)
+		v.SetInt(int64(d))
+		return v, e
+	}
+	var e error
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		var b bool
+		b, e = strconv.ParseBool(text)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, e = strconv.ParseInt(text, 10, t.Bits())
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, e = strconv.ParseUint(text, 10, t.Bits())
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, e = strconv.ParseFloat(text, t.Bits())
+		v.SetFloat(f)
+	default:
+		return v, errUnsupportedArg
+	}
+	return v, e
+}
+
+// promptArgs asks for each of the option's parameters (up to three times each), returning the function calling the
+// handler with them (which prints and returns the handler's error), or false if a value was still not accepted
+func (m *MenuTree) promptArgs(spec *argSpec) (func() error, bool) {
+	t := spec.handler.Type()
+	values := make([]reflect.Value, len(spec.args))
+	fmt.Fprintln(m.out)
+	for i, arg := range spec.args {
+		prompt := arg.Name
+		if t.In(i).Kind() != reflect.String {
+			prompt += fmt.Sprintf(" (%s)", t.In(i))
+		}
+		for attempt := 0; values[i].Kind() == reflect.Invalid; attempt++ {
+			if attempt == 3 {
+				return nil, false
+			}
+			def := arg.defaultText()
+			text := m.ReadLine(fmt.Sprintf("%s [%s]: ", prompt, def))
+			if text == "" {
+				text = def
+			}
+			v, e := parseArg(text, t.In(i))
+			if e == nil && arg.Validate != nil {
+				e = arg.Validate(text)
+			}
+			if e == nil {
+				values[i] = v
+				continue
+			}
+			fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.InvalidValue, e))
+		}
+	}
+	return m.callArgs(spec, values), true
+}
+
+// callArgs returns the function calling the handler with the values (which prints and returns the handler's error)
+func (m *MenuTree) callArgs(spec *argSpec, values []reflect.Value) func() error {
+	return func() error {
+		results := spec.handler.Call(values)
+		if len(results) == 1 && !results[0].IsNil() {
+			e := results[0].Interface().(error)
+			fmt.Fprintln(m.Writer(), fmt.Sprintf(m.Strings.OptionFailed, e))
+			return e
+		}
+		return nil
+	}
+}
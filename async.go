@@ -0,0 +1,99 @@
+package gomenutree
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// optionRuns holds the async runs and cooldowns of a tree's options, keyed by option and shared by all the tree's
+// sessions (see NewSession), so an option can not be started twice by two viewers
+type optionRuns struct {
+	mu        sync.Mutex
+	async     map[*option]*asyncStatus
+	cooldowns map[*option]time.Time
+}
+
+// asyncStatus tracks the most recent run of an async option
+type asyncStatus struct {
+	name     string
+	started  time.Time
+	finished time.Time
+	err      error
+}
+
+// AddAsyncOption will add an option whose function runs in the background while the menu stays interactive;
+// the option label shows whether it is running, done or failed (a non-nil error), along with the duration
+// (choosing it again while it is running only shows a notification, and the function should not print to the terminal;
+// a nil function is added without one, reported by Validate)
+func (m *Menu) AddAsyncOption(name string, function func() error) {
+	if function == nil {
+		m.addOption(name, &option{})
+		return
+	}
+	m.addOption(name, &option{
+		function: func() {
+			_ = function()
+		},
+		asyncFunction: function,
+	})
+}
+
+// startAsync runs the async option in a goroutine (tracked per option, for every session), redrawing the menu when it
+// finishes
+func (m *MenuTree) startAsync(menu *Menu, name string, o *option) {
+	m.runs.mu.Lock()
+	defer m.runs.mu.Unlock()
+	if s, ok := m.runs.async[o]; ok && s.finished.IsZero() {
+		return
+	}
+	s := &asyncStatus{name: name, started: time.Now()}
+	m.runs.async[o] = s
+	go func() {
+		e := m.audited(menu, name, func() error {
+			return m.invoke(name, o, nil, nil, false)
+		})
+		m.runs.mu.Lock()
+		s.finished = time.Now()
+		s.err = e
+		m.runs.mu.Unlock()
+		m.startCooldown(o)
+		m.refresh()
+	}()
+}
+
+// asyncSuffix returns the status shown after an async option's label (empty if it has never run)
+func (m *MenuTree) asyncSuffix(o *option) string {
+	if o == nil || o.asyncFunction == nil {
+		return ""
+	}
+	m.runs.mu.Lock()
+	defer m.runs.mu.Unlock()
+	s, ok := m.runs.async[o]
+	switch {
+	case !ok:
+		return ""
+	case s.finished.IsZero():
+		return " [" + fmt.Sprintf(m.Strings.Running, time.Since(s.started).Round(time.Second)) + "]"
+	case s.err != nil:
+		return " [" + fmt.Sprintf(m.Strings.Failed, s.finished.Sub(s.started).Round(time.Second), s.err) + "]"
+	default:
+		return " [" + fmt.Sprintf(m.Strings.Done, s.finished.Sub(s.started).Round(time.Second)) + "]"
+	}
+}
+
+// setIdle marks whether the event loop is waiting for input (the only time background updates may redraw)
+func (m *MenuTree) setIdle(idle bool) {
+	m.mu.Lock()
+	m.idle = idle
+	m.mu.Unlock()
+}
+
+// refresh redraws the current menu from a background goroutine, if the event loop is waiting for input
+func (m *MenuTree) refresh() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.idle {
+		m.render()
+	}
+}
@@ -0,0 +1,102 @@
+package gomenutree
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type (
+	// AuditEntry records a single option run
+	AuditEntry struct {
+		Path     []string      //menu names from the home menu down to the option's menu
+		Option   string        //option name
+		Start    time.Time     //when the option was chosen
+		Duration time.Duration //how long it ran
+		Err      error         //the error returned (async and argument options), nil otherwise
+	}
+
+	// AuditSink receives an entry for every option run (async options report from their goroutine,
+	// so sinks must be safe for concurrent use)
+	AuditSink interface {
+		Audit(entry AuditEntry)
+	}
+
+	// AuditFunc adapts a function into an AuditSink
+	AuditFunc func(entry AuditEntry)
+
+	// auditWriter writes entries as JSON lines
+	auditWriter struct {
+		mu sync.Mutex
+		w  io.Writer
+	}
+)
+
+// Audit implements AuditSink
+func (f AuditFunc) Audit(entry AuditEntry) {
+	f(entry)
+}
+
+// AuditWriter will return a sink writing each entry to w as a line of JSON
+// ({"time":...,"path":[...],"option":...,"duration_ms":...,"error":...})
+func AuditWriter(w io.Writer) AuditSink {
+	return &auditWriter{w: w}
+}
+
+// Audit implements AuditSink
+func (a *auditWriter) Audit(entry AuditEntry) {
+	record := struct {
+		Time       time.Time `json:"time"`
+		Path       []string  `json:"path"`
+		Option     string    `json:"option"`
+		DurationMS int64     `json:"duration_ms"`
+		Error      string    `json:"error,omitempty"`
+	}{entry.Start, entry.Path, entry.Option, entry.Duration.Milliseconds(), ""}
+	if entry.Err != nil {
+		record.Error = entry.Err.Error()
+	}
+	line, _ := json.Marshal(record)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, _ = a.w.Write(append(line, '\n'))
+}
+
+// audited runs the option, passing a record of the run to the audit sink and counting it in the metrics if they are set,
+// and records it as the last action
+func (m *MenuTree) audited(menu *Menu, option string, run func() error) error {
+	if m.Metrics != nil {
+		m.Metrics.IncSelection(menu.name, option)
+	}
+	start := time.Now()
+	e := run()
+	duration := time.Since(start)
+	if m.Metrics != nil {
+		m.Metrics.ObserveDuration(menu.name, option, duration, e)
+	}
+	if m.Audit != nil {
+		m.Audit.Audit(AuditEntry{Path: m.menuPath(menu), Option: option, Start: start, Duration: duration, Err: e})
+	}
+	m.trackAction(menu, option, start, e)
+	return e
+}
+
+// menuPath returns the names of the menus leading from the home menu to the given menu
+func (m *MenuTree) menuPath(menu *Menu) []string {
+	parents := map[*Menu]*Menu{m.homeMenu: nil}
+	queue := []*Menu{m.homeMenu}
+	for len(queue) > 0 && queue[0] != menu {
+		for _, sm := range m.children(queue[0]) {
+			if _, seen := parents[sm]; !seen {
+				parents[sm] = queue[0]
+				queue = append(queue, sm)
+			}
+		}
+		queue = queue[1:]
+	}
+	var path []string
+	for p := menu; p != nil; p = parents[p] {
+		path = append([]string{p.name}, path...)
+	}
+	return path
+}
@@ -0,0 +1,27 @@
+//go:build go1.21
+// +build go1.21
+
+package gomenutree
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// AuditLogger will return a sink logging each entry to the structured logger (at info level, or error level if the
+// option failed)
+func AuditLogger(logger *slog.Logger) AuditSink {
+	return AuditFunc(func(entry AuditEntry) {
+		level, attrs := slog.LevelInfo, []slog.Attr{
+			slog.String("path", strings.Join(entry.Path, "/")),
+			slog.String("option", entry.Option),
+			slog.Time("start", entry.Start),
+			slog.Duration("duration", entry.Duration),
+		}
+		if entry.Err != nil {
+			level, attrs = slog.LevelError, append(attrs, slog.Any("error", entry.Err))
+		}
+		logger.LogAttrs(context.Background(), level, "option run", attrs...)
+	})
+}
@@ -0,0 +1,41 @@
+package gomenutree
+
+// Backend is a renderer the menu tree can be shown through instead of its own ANSI terminal drawing (the default, see
+// Display), e.g. Bubble Tea (see the teamenu package) or tcell: it hosts the tree (see Host), passes the key events of
+// its own event loop to HandleKey and draws View until the tree ends, returning how it ended
+type Backend interface {
+	Run(tree *MenuTree) (ExitReason, error)
+}
+
+// HostBackend is a Backend for any event loop delivering key events (as HandleKey takes them) on Keys and drawing each
+// view with Draw; the tree ends (as stopped) if Keys is closed while it is still running
+type HostBackend struct {
+	Keys <-chan string
+	Draw func(view string)
+}
+
+// DisplayWith will show the menu tree through the backend until it ends, returning how it ended; a nil backend draws
+// on the terminal like Display
+func (m *MenuTree) DisplayWith(backend Backend) (ExitReason, error) {
+	if backend == nil {
+		return m.Display()
+	}
+	return backend.Run(m)
+}
+
+// Run implements Backend
+func (b HostBackend) Run(tree *MenuTree) (ExitReason, error) {
+	tree.Host()
+	b.Draw(tree.View())
+	for key := range b.Keys {
+		running := tree.HandleKey(key)
+		b.Draw(tree.View())
+		if !running {
+			return tree.ExitReason()
+		}
+	}
+	if tree.displaying {
+		tree.end(ExitStopped)
+	}
+	return tree.ExitReason()
+}
@@ -0,0 +1,148 @@
+package gomenutree
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Run will walk the path of submenu names from the home menu (optionally starting with its name) and run the option
+// named last, without drawing the menu or waiting for keys, so scripts can take the same actions as the interactive
+// menu (e.g. tree.Run(os.Args[1:]...) for `mytool Settings Network "Restart DHCP"`); names are matched exactly
+// first, then case insensitively, and the path elements after an option are its arguments (see AddArgOption, missing
+// ones take their defaults). Menu loaders run as the menus are entered, async options run to completion, and the
+// option's output goes to the menu tree's output; an error is returned if the path does not lead to an option, the
+// option is disabled, protected, destructive, cooling down or given too many arguments, or if it fails
+func (m *MenuTree) Run(path ...string) error {
+	if len(path) > 0 && (path[0] == m.homeMenu.name || strings.EqualFold(path[0], m.homeMenu.name)) {
+		path = path[1:]
+	}
+	if len(path) == 0 {
+		return fmt.Errorf("gomenutree: no option given")
+	}
+	menu := m.homeMenu
+	if e := m.batchEnter(menu); e != nil {
+		return e
+	}
+	for i, name := range path {
+		if index := m.optionNamed(menu, name); index >= 0 {
+			return m.batchRun(menu, menu.optionsOrder[index], path[i+1:])
+		}
+		next := m.subMenuNamed(menu, name)
+		if next == nil || !m.visibleSubMenu(menu, next) {
+			return fmt.Errorf("gomenutree: no option or submenu %q in menu %q", name, menu.name)
+		}
+		if next.gate != nil {
+			return fmt.Errorf("gomenutree: menu %q is protected", next.name)
+		}
+		if e := m.batchEnter(next); e != nil {
+			return e
+		}
+		menu = next
+	}
+	return fmt.Errorf("gomenutree: %q is a menu, not an option", menu.name)
+}
+
+// optionNamed returns the index of the visible action option of the menu with the given name (exact match first, then
+// case insensitive), or -1
+func (m *MenuTree) optionNamed(menu *Menu, name string) int {
+	for _, exact := range []bool{true, false} {
+		for i, n := range menu.optionsOrder {
+			o := menu.options[n]
+			if o.separator || o.subMenu != nil || o.jump != nil || !m.visible(menu, i) {
+				continue
+			}
+			if (exact && n == name) || (!exact && strings.EqualFold(n, name)) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// visibleSubMenu reports whether the submenu of the menu is visible (listed in its submenus or among its options)
+func (m *MenuTree) visibleSubMenu(menu *Menu, subMenu *Menu) bool {
+	for i, name := range menu.optionsOrder {
+		if menu.options[name].subMenu == subMenu {
+			return m.visible(menu, i)
+		}
+	}
+	for i, sm := range m.subMenuMap[menu] {
+		if sm == subMenu {
+			return m.visible(menu, i+len(menu.optionsOrder))
+		}
+	}
+	return false
+}
+
+// batchEnter runs the menu's loader, if it has one
+func (m *MenuTree) batchEnter(menu *Menu) error {
+	if menu.onEnter == nil {
+		return nil
+	}
+	if e := menu.onEnter(context.Background()); e != nil {
+		return fmt.Errorf("gomenutree: loading menu %q: %w", menu.name, e)
+	}
+	m.refreshCopy(menu)
+	return nil
+}
+
+// batchRun runs the option of the menu with the arguments given
+func (m *MenuTree) batchRun(menu *Menu, name string, args []string) error {
+	o := menu.options[name]
+	switch {
+	case o.disabled:
+		return fmt.Errorf("gomenutree: option %q is disabled: %s", name, o.reason)
+	case o.gate != nil:
+		return fmt.Errorf("gomenutree: option %q is protected", name)
+	case o.confirmPhrase != "":
+		return fmt.Errorf("gomenutree: option %q is destructive and needs a typed confirmation", name)
+	case o.args == nil && len(args) > 0:
+		return fmt.Errorf("gomenutree: option %q takes no arguments (given %q)", name, args)
+	}
+	if reason := m.blockReason(name, o); reason != "" {
+		return fmt.Errorf("gomenutree: %s", reason)
+	}
+	var call func() error
+	if o.args != nil {
+		f, e := m.batchArgs(name, o.args, args)
+		if e != nil {
+			return e
+		}
+		call = f
+	}
+	m.debug("batch run", "menu", menu.name, "option", name)
+	m.trackUsage(menu, name)
+	e := m.audited(menu, name, func() error {
+		return m.invoke(name, o, call, os.Stdin, false)
+	})
+	m.startCooldown(o)
+	return e
+}
+
+// batchArgs converts and validates the arguments given for the option's parameters (defaults for those missing),
+// returning the function calling its handler
+func (m *MenuTree) batchArgs(name string, spec *argSpec, args []string) (func() error, error) {
+	if len(args) > len(spec.args) {
+		return nil, fmt.Errorf("gomenutree: option %q takes %d arguments (given %d)", name, len(spec.args), len(args))
+	}
+	t := spec.handler.Type()
+	values := make([]reflect.Value, len(spec.args))
+	for i, arg := range spec.args {
+		text := arg.defaultText()
+		if i < len(args) {
+			text = args[i]
+		}
+		v, e := parseArg(text, t.In(i))
+		if e == nil && arg.Validate != nil {
+			e = arg.Validate(text)
+		}
+		if e != nil {
+			return nil, fmt.Errorf("gomenutree: option %q: %s: %w", name, arg.Name, e)
+		}
+		values[i] = v
+	}
+	return m.callArgs(spec, values), nil
+}
@@ -0,0 +1,120 @@
+package gomenutree
+
+import (
+	"strings"
+)
+
+// Border holds the characters used to draw a four-sided box around a menu (empty sides are left out)
+type Border struct {
+	TopLeft, Top, TopRight          string
+	Left, Right                     string
+	BottomLeft, Bottom, BottomRight string
+}
+
+var (
+	// BorderNone draws no frame at all, just the padding
+	BorderNone = Border{}
+	// BorderASCII draws the box with plain ASCII characters for terminals without Unicode support
+	BorderASCII = Border{"+", "-", "+", "|", "|", "+", "-", "+"}
+	// BorderSingle draws the box with single Unicode box-drawing lines
+	BorderSingle = Border{"┌", "─", "┐", "│", "│", "└", "─", "┘"}
+	// BorderDouble draws the box with double Unicode box-drawing lines
+	BorderDouble = Border{"╔", "═", "╗", "║", "║", "╚", "═", "╝"}
+	// BorderRounded draws the box with single Unicode box-drawing lines and rounded corners
+	BorderRounded = Border{"╭", "─", "╮", "│", "│", "╰", "─", "╯"}
+)
+
+// box will frame the menu lines (the footer last) with the configured border, recording the widest line
+// and how many rows are drawn below the footer; with TitleInBorder the title and banner are set into the top border
+func (m *MenuTree) box(title string, banner string, lines []string, menuStyle Style) string {
+	b := m.Border
+	if m.TitleInBorder && (b == nil || b.Top != "") {
+		lines = lines[1:]
+		title = apply(menuStyle.Title, title)
+		if banner != "" {
+			title += "  " + banner
+		}
+	} else {
+		title = ""
+	}
+	if b == nil {
+		lines = m.fit(lines, m.width-4)
+	} else {
+		lines = m.fit(lines, m.width-displayWidth(b.Left)-displayWidth(b.Right)-2*m.Padding)
+	}
+	state := m.state(m.currentMenu)
+	state.longestLine = 0
+	for _, l := range lines {
+		if w := displayWidth(l); w > state.longestLine {
+			state.longestLine = w
+		}
+	}
+	var sb strings.Builder
+	sb.Grow(len(lines) * (state.longestLine + 16))
+	sb.WriteString("\n")
+	if b == nil {
+		state.longestLine += 2
+		borderLength := state.longestLine + 4
+		if m.width > 0 && borderLength > m.width {
+			borderLength = m.width
+		}
+		sb.WriteString(borderTop("", "*", "", title, borderLength, menuStyle) + "\n")
+		for idx, l := range lines {
+			fillLength := state.longestLine - displayWidth(l)
+			if idx < len(lines)-1 {
+				sb.WriteString("  " + l + "\n")
+			} else {
+				if fillLength < 0 {
+					fillLength = 0
+				}
+				sb.WriteString(apply(menuStyle.Frame, "**") + l + apply(menuStyle.Frame, strings.Repeat("*", fillLength)+"**"))
+			}
+		}
+		m.footerRows = 0
+		return sb.String()
+	}
+	padding := ""
+	if m.Padding > 0 {
+		padding = strings.Repeat(" ", m.Padding)
+	}
+	inner := state.longestLine + 2*len(padding)
+	if w := displayWidth(title) + 4; w > inner {
+		inner = w
+	}
+	if b.Top != "" || b.TopLeft != "" || b.TopRight != "" {
+		sb.WriteString(borderTop(b.TopLeft, b.Top, b.TopRight, title, inner, menuStyle) + "\n")
+	}
+	for idx, l := range lines {
+		fill := inner - 2*len(padding) - displayWidth(l)
+		if fill < 0 {
+			fill = 0
+		}
+		sb.WriteString(apply(menuStyle.Frame, b.Left) + padding + l + strings.Repeat(" ", fill) + padding + apply(menuStyle.Frame, b.Right))
+		if idx < len(lines)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	m.footerRows = 0
+	if b.Bottom != "" || b.BottomLeft != "" || b.BottomRight != "" {
+		sb.WriteString("\n" + borderTop(b.BottomLeft, b.Bottom, b.BottomRight, "", inner, menuStyle))
+		m.footerRows = 1
+	}
+	state.longestLine = inner + displayWidth(b.Left) + displayWidth(b.Right) - 2
+	return sb.String()
+}
+
+// borderTop draws a horizontal border of the given inner width, with the (styled) title, if any, set into it
+func borderTop(leftCorner, edge, rightCorner, title string, width int, menuStyle Style) string {
+	if edge == "" {
+		edge = " "
+	}
+	if title == "" {
+		return apply(menuStyle.Frame, leftCorner+strings.Repeat(edge, width)+rightCorner)
+	}
+	fill := width - displayWidth(title) - 4
+	if fill < 0 {
+		fill = 0
+	}
+	return apply(menuStyle.Frame, leftCorner+strings.Repeat(edge, 2)) + " " + title + " " +
+		apply(menuStyle.Frame, strings.Repeat(edge, fill)+rightCorner)
+}
@@ -0,0 +1,69 @@
+package gomenutree
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// clipboardCommands are the platform clipboard tools tried in order, each reading the text on stdin
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// LastOutput will return the captured output of the last option run (only captured while CopyKey is set)
+func (m *MenuTree) LastOutput() []string {
+	return m.lastOutput
+}
+
+// runCopyable runs the option function with its output shown as usual and also kept for copying
+func (m *MenuTree) runCopyable(function func()) {
+	if m.CopyKey == "" {
+		function()
+		return
+	}
+	m.lastOutput = m.captureTee(function, m.out)
+}
+
+// waitToContinue waits for a key after option output, copying the output to the clipboard each time CopyKey is pressed
+func (m *MenuTree) waitToContinue() {
+	if m.CopyKey == "" {
+		fmt.Fprintln(m.out, m.continuePrompt())
+		m.getInput()
+		return
+	}
+	fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.PressAnyKeyCopy, m.CopyKey))
+	for strings.EqualFold(m.getInput(), m.CopyKey) {
+		if e := m.copyToClipboard(strings.Join(m.lastOutput, "\n")); e != nil {
+			fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.CopyFailed, e))
+		} else {
+			fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.Copied, len(m.lastOutput)))
+		}
+	}
+}
+
+// copyToClipboard copies the text with CopyFunc if set, otherwise with the first platform clipboard tool found
+// (local terminals only), falling back to the OSC 52 escape sequence understood by most terminal emulators
+// (which also reaches the client's clipboard over SSH)
+func (m *MenuTree) copyToClipboard(text string) error {
+	if m.CopyFunc != nil {
+		return m.CopyFunc(text)
+	}
+	if m.in == nil {
+		for _, c := range clipboardCommands {
+			if _, e := exec.LookPath(c[0]); e != nil {
+				continue
+			}
+			cmd := exec.Command(c[0], c[1:]...)
+			cmd.Stdin = strings.NewReader(text)
+			return cmd.Run()
+		}
+	}
+	_, e := fmt.Fprint(m.terminal(), "\033]52;c;"+base64.StdEncoding.EncodeToString([]byte(text))+"\a")
+	return e
+}
@@ -0,0 +1,50 @@
+package gomenutree
+
+// Clone will return a copy of the menu (name, prompt, options and settings) that can be customized, e.g. options
+// deleted or prompts changed, without affecting the original; options are shared unless deep, in which case each
+// option is copied too, so per-option settings (DisableOption, SetOptionHotKey...) can also differ
+func (m *Menu) Clone(deep bool) *Menu {
+	c := *m
+	c.options = make(map[string]*option, len(m.options))
+	for name, o := range m.options {
+		if deep {
+			oc := *o
+			if o.command != nil { //so SetCommandDir and SetCommandEnv do not change the original
+				cc := *o.command
+				cc.env = append([]string(nil), cc.env...)
+				oc.command, oc.function = &cc, cc.function()
+			}
+			o = &oc
+		}
+		c.options[name] = o
+	}
+	c.optionsOrder = append([]string(nil), m.optionsOrder...)
+	return &c
+}
+
+// Clone will return a copy of the menu tree and its configuration, with every menu deep cloned (see Menu.Clone), so a
+// template tree can be customized per tenant or environment; menus of the copy are found with FindMenu
+func (m *MenuTree) Clone() *MenuTree {
+	clones := make(map[*Menu]*Menu)
+	var clone func(menu *Menu) *Menu
+	clone = func(menu *Menu) *Menu {
+		if c, ok := clones[menu]; ok {
+			return c
+		}
+		c := menu.Clone(true)
+		clones[menu] = c
+		for _, o := range c.options {
+			if o.subMenu != nil {
+				o.subMenu = clone(o.subMenu)
+			}
+		}
+		return c
+	}
+	c := m.configured(clone(m.homeMenu))
+	for parent, children := range m.subMenuMap {
+		for _, child := range children {
+			c.subMenuMap[clone(parent)] = append(c.subMenuMap[clone(parent)], clone(child))
+		}
+	}
+	return c
+}
@@ -0,0 +1,75 @@
+// Package cobramenu builds a browsable gomenutree menu from a cobra command tree
+package cobramenu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mikefrom1974/gomenutree"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// NewMenuTree will walk the command tree starting at root and return a menu tree where commands with
+// subcommands become submenus and runnable commands become options; choosing an option prompts for the
+// command's flags and arguments, then executes it through root (so persistent hooks and flags still apply)
+func NewMenuTree(root *cobra.Command) *gomenutree.MenuTree {
+	home := gomenutree.NewMenu(root.Name(), root.Short, nil)
+	mTree := gomenutree.NewMenuTree(home)
+	addCommands(mTree, root, home, root, nil)
+	return mTree
+}
+
+// addCommands adds the available subcommands of cmd to menu, recursing into commands that have subcommands
+func addCommands(mTree *gomenutree.MenuTree, root *cobra.Command, menu *gomenutree.Menu, cmd *cobra.Command, path []string) {
+	if cmd.Runnable() && cmd != root {
+		menu.AddOption("run "+cmd.Name(), runner(mTree, root, cmd, path))
+	}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		cPath := append(append([]string{}, path...), c.Name())
+		if c.HasAvailableSubCommands() {
+			sub := gomenutree.NewMenu(c.Name(), c.Short, nil)
+			mTree.AddSubMenu(menu, sub)
+			addCommands(mTree, root, sub, c, cPath)
+		} else {
+			menu.AddOption(c.Name(), runner(mTree, root, c, cPath))
+		}
+	}
+}
+
+// runner returns the option function for a command: prompt for each visible flag (empty keeps the default),
+// then for positional arguments if the usage line declares any, and execute the command, its output (and cobra's
+// usage and errors) going to the menu's Writer
+func runner(mTree *gomenutree.MenuTree, root *cobra.Command, cmd *cobra.Command, path []string) func() {
+	return func() {
+		out := mTree.Writer()
+		args := append([]string{}, path...)
+		if cmd.Short != "" {
+			fmt.Fprintln(out, cmd.Short)
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if f.Hidden || f.Name == "help" {
+				return
+			}
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+			v := mTree.ReadLine(fmt.Sprintf("--%s (%s) [%s]: ", f.Name, f.Usage, f.DefValue))
+			if v != "" {
+				args = append(args, fmt.Sprintf("--%s=%s", f.Name, v))
+			}
+		})
+		if strings.Contains(cmd.Use, " ") {
+			line := mTree.ReadLine(fmt.Sprintf("arguments (%s): ", cmd.Use))
+			args = append(args, strings.Fields(line)...)
+		}
+		root.SetArgs(args)
+		root.SetOut(out)
+		root.SetErr(out)
+		if e := root.Execute(); e != nil {
+			fmt.Fprintln(out, "Error:", e)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/mikefrom1974/gomenutree/cobramenu
+
+go 1.18
+
+require (
+	github.com/mikefrom1974/gomenutree v1.1.0
+	github.com/spf13/cobra v1.8.1
+	github.com/spf13/pflag v1.0.5
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	golang.org/x/term v0.6.0 // indirect
+)
+
+replace github.com/mikefrom1974/gomenutree => ../
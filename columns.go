@@ -0,0 +1,61 @@
+package gomenutree
+
+import (
+	"strings"
+)
+
+// SetColumns will set the maximum number of columns this menu's options are laid out in (overriding
+// MenuTree.Columns, 0 uses the tree's setting); left/right move between columns
+func (m *Menu) SetColumns(columns int) {
+	m.columns = columns
+}
+
+// arrangeColumns lays the option cells out top to bottom in as many columns as configured and as fit the terminal
+func (m *MenuTree) arrangeColumns(cells []string) []string {
+	m.state(m.currentMenu).columnRows = 0
+	columns := m.Columns
+	if m.currentMenu.columns > 0 {
+		columns = m.currentMenu.columns
+	}
+	cellWidth := 0
+	for _, c := range cells {
+		if w := displayWidth(c); w > cellWidth {
+			cellWidth = w
+		}
+	}
+	for columns > 1 && m.width > 0 && columns*(cellWidth+3)+6 > m.width {
+		columns--
+	}
+	if columns <= 1 || len(cells) < 2 {
+		return cells
+	}
+	rows := (len(cells) + columns - 1) / columns
+	m.state(m.currentMenu).columnRows = rows
+	lines := make([]string, rows)
+	for i, c := range cells {
+		row := i % rows
+		lines[row] += strings.Repeat(" ", (i/rows)*(cellWidth+3)-displayWidth(lines[row]))
+		lines[row] += c
+	}
+	return lines
+}
+
+// moveColumn moves the selection cursor to the same row of the neighbouring column, reporting whether it moved
+func (m *MenuTree) moveColumn(delta int) bool {
+	state := m.state(m.currentMenu)
+	if state.columnRows == 0 {
+		return false
+	}
+	for p, idx := range state.cellIndexes {
+		if idx != state.selection {
+			continue
+		}
+		target := p + delta*state.columnRows
+		if target < 0 || target >= len(state.cellIndexes) || !m.selectable(state.cellIndexes[target]) {
+			return false
+		}
+		state.selection = state.cellIndexes[target]
+		return true
+	}
+	return false
+}
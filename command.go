@@ -0,0 +1,80 @@
+package gomenutree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// command is an external program run by a command option
+type command struct {
+	argv []string
+	dir  string
+	env  []string
+}
+
+// AddCommandOption will add an option running an external command (argv is the program then its arguments, no shell
+// is involved): its output is streamed into the option output (or pager) and its exit status shown once it ends;
+// see SetCommandDir and SetCommandEnv for where and how it runs
+func (m *Menu) AddCommandOption(name string, argv ...string) {
+	c := &command{argv: argv}
+	m.addOption(name, &option{function: c.function(), command: c})
+}
+
+// SetCommandDir will set the working directory of the named command option ("" is the menu program's)
+func (m *Menu) SetCommandDir(name string, dir string) {
+	if o, ok := m.options[name]; ok && o.command != nil {
+		o.command.dir = dir
+	}
+}
+
+// SetCommandEnv will add "KEY=value" entries to the environment the named command option runs with (on top of the
+// menu program's)
+func (m *Menu) SetCommandEnv(name string, env ...string) {
+	if o, ok := m.options[name]; ok && o.command != nil {
+		o.command.env = append(o.command.env, env...)
+	}
+}
+
+// function returns the option function running the command outside the menu (e.g. as a macro step), without its
+// exit status
+func (c *command) function() func() {
+	return func() {
+		_ = c.run(context.Background(), os.Stdin, os.Stdout)
+	}
+}
+
+// run runs the command reading in (nil for no input) with its output (and errors) written to out, killing it if the
+// context is cancelled, returning its error
+func (c *command) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	if len(c.argv) == 0 {
+		return errors.New("gomenutree: no command to run")
+	}
+	cmd := exec.CommandContext(ctx, c.argv[0], c.argv[1:]...)
+	cmd.Dir = c.dir
+	if len(c.env) > 0 {
+		cmd.Env = append(os.Environ(), c.env...)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = in, out, out
+	return cmd.Run()
+}
+
+// runCommand runs the command option reading in, showing its exit status (or why it could not run)
+func (m *MenuTree) runCommand(ctx context.Context, c *command, in io.Reader) error {
+	out := m.Writer()
+	e := c.run(ctx, in, out)
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil: //killed, reported as timed out
+	case e == nil:
+		fmt.Fprintln(out, fmt.Sprintf(m.Strings.ExitStatus, 0))
+	case errors.As(e, &exitErr):
+		fmt.Fprintln(out, fmt.Sprintf(m.Strings.ExitStatus, exitErr.ExitCode()))
+	default:
+		fmt.Fprintln(out, fmt.Sprintf(m.Strings.OptionFailed, e))
+	}
+	return e
+}
@@ -0,0 +1,92 @@
+package gomenutree
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CompletionCommand is the first argument the completion scripts call the program with (see CompletionScript),
+// followed by the words typed so far, the last being the one completed
+const CompletionCommand = "__complete"
+
+// nonIdentifier matches the characters of a program name that can not be used in a shell function name
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// Complete will return the submenu and option names that can follow the path typed so far for Run, the last argument
+// being the (possibly empty) prefix of the word completed, matched case insensitively; nothing is offered after an
+// option (its arguments) and menu loaders are not run, e.g.
+//
+//	if len(os.Args) > 1 && os.Args[1] == gomenutree.CompletionCommand {
+//		fmt.Println(strings.Join(tree.Complete(os.Args[2:]...), "\n"))
+//		return
+//	}
+func (m *MenuTree) Complete(args ...string) []string {
+	prefix := ""
+	if len(args) > 0 {
+		args, prefix = args[:len(args)-1], args[len(args)-1]
+	}
+	if len(args) > 0 && (args[0] == m.homeMenu.name || strings.EqualFold(args[0], m.homeMenu.name)) {
+		args = args[1:]
+	}
+	menu := m.homeMenu
+	for _, name := range args {
+		if m.optionNamed(menu, name) >= 0 {
+			return nil
+		}
+		next := m.subMenuNamed(menu, name)
+		if next == nil || next.gate != nil || !m.visibleSubMenu(menu, next) {
+			return nil
+		}
+		menu = next
+	}
+	var candidates []string
+	add := func(name string) {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			candidates = append(candidates, name)
+		}
+	}
+	for i, name := range menu.optionsOrder {
+		o := menu.options[name]
+		if o.separator || o.jump != nil || (o.hidden && !m.revealed) || !m.visible(menu, i) {
+			continue
+		}
+		add(name)
+	}
+	for i, sm := range m.subMenuMap[menu] {
+		if m.visible(menu, i+len(menu.optionsOrder)) {
+			add(sm.name)
+		}
+	}
+	return candidates
+}
+
+// CompletionScript will return a script for the shell ("bash", "zsh" or "fish") completing the menu path arguments
+// of the program (its Run form), by calling it with CompletionCommand and the words typed so far, to be sourced from
+// the shell's startup file (e.g. `source <(mytool completion bash)`)
+func (m *MenuTree) CompletionScript(shell string, program string) (string, error) {
+	function := "_" + nonIdentifier.ReplaceAllString(program, "_") + "_complete"
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(`%[1]s() {
+	local IFS=$'\n' words=("${COMP_WORDS[@]:1:COMP_CWORD}")
+	COMPREPLY=($(%[2]s %[3]s "${words[@]//\\ / }" 2>/dev/null))
+	COMPREPLY=("${COMPREPLY[@]// /\\ }")
+}
+complete -F %[1]s %[2]s
+`, function, program, CompletionCommand), nil
+	case "zsh":
+		return fmt.Sprintf(`#compdef %[2]s
+%[1]s() {
+	local -a candidates
+	candidates=("${(@f)$(%[2]s %[3]s "${(@Q)words[2,CURRENT]}" 2>/dev/null)}")
+	compadd -a candidates
+}
+compdef %[1]s %[2]s
+`, function, program, CompletionCommand), nil
+	case "fish":
+		return fmt.Sprintf("complete -c %[1]s -f -a '(%[1]s %[2]s (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'\n",
+			program, CompletionCommand), nil
+	}
+	return "", fmt.Errorf("gomenutree: no completion script for shell %q", shell)
+}
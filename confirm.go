@@ -0,0 +1,37 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetDestructive will flag the named option as destructive: before it runs, the user has to type the phrase (e.g. the
+// name of the resource deleted) at an inline prompt below the menu, anything else cancels it ("" removes the flag)
+func (m *Menu) SetDestructive(name string, phrase string) {
+	if o, ok := m.options[name]; ok {
+		o.confirmPhrase = phrase
+	}
+}
+
+// confirmed asks for the option's confirmation phrase, reporting whether it was typed exactly; if it was not, the
+// option is not run and a message is shown until a key is pressed
+func (m *MenuTree) confirmed(name string, phrase string) bool {
+	fmt.Fprintln(m.out)
+	typed := m.ReadLine(fmt.Sprintf(m.Strings.ConfirmPhrase, phrase))
+	lines := 2
+	if strings.TrimSpace(typed) == phrase {
+		m.debug("destructive option confirmed", "option", name)
+		m.state(m.currentMenu).lastRenderLines += lines
+		return true
+	}
+	m.debug("destructive option not confirmed", "option", name)
+	if m.hosted {
+		return false
+	}
+	fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.NotConfirmed, name))
+	fmt.Fprintln(m.out, m.continuePrompt())
+	m.state(m.currentMenu).lastRenderLines += lines + 2
+	m.getInput()
+	m.render()
+	return false
+}
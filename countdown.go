@@ -0,0 +1,78 @@
+package gomenutree
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetAutoRun will make the named option or submenu the default selection and choose it automatically once the menu
+// has been shown for the given time without a key being pressed, with a countdown below the entries
+// (e.g. for boot menus and kiosks; any key cancels the countdown until the menu is entered again, 0 disables)
+func (m *Menu) SetAutoRun(name string, after time.Duration) {
+	m.autoRun = name
+	m.autoRunAfter = after
+	if after > 0 {
+		m.SetDefaultSelectionName(name)
+	}
+}
+
+// startCountdown will start the current menu's auto-run countdown, if it has one
+func (m *MenuTree) startCountdown() {
+	m.countdownEnd = time.Time{}
+	if m.currentMenu.autoRun != "" && m.currentMenu.autoRunAfter > 0 && !m.hosted {
+		m.countdownEnd = time.Now().Add(m.currentMenu.autoRunAfter)
+	}
+}
+
+// countingDown reports whether an auto-run countdown is active
+func (m *MenuTree) countingDown() bool {
+	return !m.countdownEnd.IsZero()
+}
+
+// countdownTimeout returns how long to wait for a key before updating the countdown (or for the idle timeout)
+func (m *MenuTree) countdownTimeout() time.Duration {
+	if !m.countingDown() {
+		return m.idleTimeout
+	}
+	remaining := time.Until(m.countdownEnd)
+	if step := remaining - remaining.Truncate(time.Second); step > 0 {
+		return step
+	}
+	if remaining < time.Second {
+		return remaining
+	}
+	return time.Second
+}
+
+// tickCountdown updates the countdown, choosing the auto-run entry once it runs out
+func (m *MenuTree) tickCountdown() {
+	if time.Now().Before(m.countdownEnd) {
+		if !m.lineMode {
+			m.render()
+		}
+		return
+	}
+	m.countdownEnd = time.Time{}
+	if index := entryIndex(m, m.currentMenu, m.currentMenu.autoRun); index >= 0 {
+		m.debug("auto-run", "menu", m.currentMenu.name, "entry", m.currentMenu.autoRun)
+		m.state(m.currentMenu).selection = index
+		if m.Redraw {
+			m.render()
+		}
+		m.execute(index)
+		return
+	}
+	m.render()
+}
+
+// countdownLine returns the countdown text shown below the entries (empty if there is no countdown)
+func (m *MenuTree) countdownLine() string {
+	if !m.countingDown() {
+		return ""
+	}
+	seconds := int((time.Until(m.countdownEnd) + time.Second - 1) / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	return fmt.Sprintf(m.Strings.Countdown, m.currentMenu.autoRun, seconds)
+}
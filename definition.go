@@ -0,0 +1,84 @@
+package gomenutree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type (
+	// MenuDefinition describes a menu, its options and its submenus, so a whole tree can live in a config document
+	// (JSON is read directly with NewMenuTreeFromJSON; YAML can be decoded into this struct by the user's YAML library)
+	MenuDefinition struct {
+		Name     string             `json:"name" yaml:"name"`
+		Prompt   string             `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+		Options  []OptionDefinition `json:"options,omitempty" yaml:"options,omitempty"`
+		SubMenus []MenuDefinition   `json:"submenus,omitempty" yaml:"submenus,omitempty"`
+	}
+
+	// OptionDefinition describes a single option, with Action referencing a function registered in an ActionRegistry
+	OptionDefinition struct {
+		Name   string `json:"name" yaml:"name"`
+		Action string `json:"action,omitempty" yaml:"action,omitempty"`
+	}
+
+	// ActionRegistry maps the action names used in menu definitions to the functions they run
+	ActionRegistry map[string]func()
+)
+
+// NewMenuTreeFromJSON will decode a JSON menu definition and build a menu tree from it (see NewMenuTreeFromDefinition)
+func NewMenuTreeFromJSON(r io.Reader, actions ActionRegistry) (*MenuTree, error) {
+	var def MenuDefinition
+	if e := json.NewDecoder(r).Decode(&def); e != nil {
+		return nil, fmt.Errorf("gomenutree: decoding menu definition: %w", e)
+	}
+	return NewMenuTreeFromDefinition(def, actions)
+}
+
+// NewMenuTreeFromDefinition will build a menu tree with def as the home menu, resolving every option action
+// through the registry (an unknown action or unnamed menu/option is an error)
+func NewMenuTreeFromDefinition(def MenuDefinition, actions ActionRegistry) (*MenuTree, error) {
+	home, e := def.menu(actions)
+	if e != nil {
+		return nil, e
+	}
+	m := NewMenuTree(home)
+	if e := m.addDefinedSubMenus(home, def.SubMenus, actions); e != nil {
+		return nil, e
+	}
+	return m, nil
+}
+
+// addDefinedSubMenus recursively builds and attaches the defined submenus to the parent menu
+func (m *MenuTree) addDefinedSubMenus(parentMenu *Menu, defs []MenuDefinition, actions ActionRegistry) error {
+	for _, d := range defs {
+		child, e := d.menu(actions)
+		if e != nil {
+			return e
+		}
+		m.AddSubMenu(parentMenu, child)
+		if e := m.addDefinedSubMenus(child, d.SubMenus, actions); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// menu builds a single menu (without submenus) from the definition
+func (d MenuDefinition) menu(actions ActionRegistry) (*Menu, error) {
+	if d.Name == "" {
+		return nil, fmt.Errorf("gomenutree: menu definition without a name")
+	}
+	menu := NewMenu(d.Name, d.Prompt, nil)
+	for _, o := range d.Options {
+		if o.Name == "" {
+			return nil, fmt.Errorf("gomenutree: option without a name in menu %q", d.Name)
+		}
+		f, ok := actions[o.Action]
+		if !ok || f == nil {
+			return nil, fmt.Errorf("gomenutree: unknown action %q for option %q in menu %q", o.Action, o.Name, d.Name)
+		}
+		menu.AddOption(o.Name, f)
+	}
+	return menu, nil
+}
@@ -0,0 +1,46 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// frame builds the current menu frame, indented by the menu's depth in the tree when IndentDepth is set
+func (m *MenuTree) frame() string {
+	indent := 0
+	if m.IndentDepth > 0 {
+		indent = m.IndentDepth * (len(m.menuPath(m.currentMenu)) - 1)
+	}
+	if indent <= 0 {
+		return m.menuFrame()
+	}
+	if width := m.width; width > 0 { //lines are fitted to what is left of the terminal width
+		if m.width -= indent; m.width < 20 {
+			m.width = 20
+		}
+		defer func() {
+			m.width = width
+		}()
+	}
+	lines := strings.Split(m.menuFrame(), "\n")
+	padding := strings.Repeat(" ", indent)
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = padding + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateTitle sets the terminal window title to the current menu's breadcrumb, if it changed (see TerminalTitle)
+func (m *MenuTree) updateTitle() {
+	if !m.TerminalTitle || !m.ownsTerminal() {
+		return
+	}
+	title := sanitize(strings.Join(m.menuPath(m.currentMenu), m.Strings.TitleSeparator))
+	if title == m.title {
+		return
+	}
+	m.title = title
+	fmt.Fprint(m.out, "\033]0;"+title+"\a")
+}
@@ -0,0 +1,138 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alert will show the title and body in a dialog drawn over the menu (title in red) until a key is pressed, then
+// redraw the menu; called while an option runs, the dialog is drawn below its output and removed afterwards
+func (m *MenuTree) Alert(title string, body string) {
+	m.dialog(title, body, LevelError)
+}
+
+// Info will show a dialog like Alert, its title in cyan
+func (m *MenuTree) Info(title string, body string) {
+	m.dialog(title, body, LevelInfo)
+}
+
+// Warning will show a dialog like Alert, its title in yellow
+func (m *MenuTree) Warning(title string, body string) {
+	m.dialog(title, body, LevelWarning)
+}
+
+// dialog draws the dialog over the menu frame (or below the output of a running option), waits for a key and
+// restores what was there
+func (m *MenuTree) dialog(title string, body string, level Level) {
+	m.detectSize()
+	box := m.dialogBox(title, body, level)
+	if !m.ownsTerminal() {
+		fmt.Fprintln(m.out, "\n"+strings.Join(box, "\n"))
+		m.getInput()
+		return
+	}
+	if m.inOption {
+		text := "\n" + strings.Join(box, "\n")
+		fmt.Fprint(m.out, text)
+		m.getInput()
+		fmt.Fprintf(m.out, "\033[%dA\r\033[J", m.rows(text))
+		return
+	}
+	state := m.state(m.currentMenu)
+	if state.lastRenderLines > 0 && m.Redraw {
+		fmt.Fprintf(m.out, "\033[%dA", state.lastRenderLines)
+	}
+	text := "\n" + strings.Join(overlay(strings.Split(strings.TrimPrefix(m.frame(), "\n"), "\n"), box), "\n")
+	fmt.Fprint(m.out, text+"\033[J")
+	m.getInput()
+	if rows := m.rows(text); rows > 1 { //clear the dialog, the frame is drawn again from the same line
+		fmt.Fprintf(m.out, "\033[%dA\r\033[J\033[A", rows-1)
+	}
+	state.lastRenderLines = 0
+	m.render()
+}
+
+// dialogBox returns the rows of the dialog: the body (wrapped to the terminal width) and the continue prompt in a
+// rounded box, with the title set into the top border
+func (m *MenuTree) dialogBox(title string, body string, level Level) []string {
+	b := BorderRounded
+	var lines []string
+	for _, l := range strings.Split(strings.Replace(body, "\r", "", -1), "\n") {
+		l = sanitize(l)
+		if m.width > 8 && displayWidth(l) > m.width-4 {
+			lines = append(lines, wrapStyled(l, m.width-4)...)
+		} else {
+			lines = append(lines, l)
+		}
+	}
+	lines = append(lines, "", m.continuePrompt())
+	title = sanitize(title)
+	inner := displayWidth(title) + 4
+	for _, l := range lines {
+		if w := displayWidth(l) + 2; w > inner {
+			inner = w
+		}
+	}
+	box := make([]string, 0, len(lines)+2)
+	box = append(box, b.TopLeft+b.Top+" "+level.color().Color(title)+" "+strings.Repeat(b.Top, inner-displayWidth(title)-3)+b.TopRight)
+	for _, l := range lines {
+		box = append(box, b.Left+" "+l+strings.Repeat(" ", inner-displayWidth(l)-1)+b.Right)
+	}
+	return append(box, b.BottomLeft+strings.Repeat(b.Bottom, inner)+b.BottomRight)
+}
+
+// overlay draws the box centered over the (unstyled) frame lines, adding rows if the frame is shorter
+func overlay(lines []string, box []string) []string {
+	for len(lines) < len(box) {
+		lines = append(lines, "")
+	}
+	frameWidth, boxWidth := 0, displayWidth(box[0])
+	for i, l := range lines {
+		lines[i] = StripANSI(l)
+		if w := displayWidth(lines[i]); w > frameWidth {
+			frameWidth = w
+		}
+	}
+	top, left := (len(lines)-len(box))/2, 0
+	if frameWidth > boxWidth {
+		left = (frameWidth - boxWidth) / 2
+	}
+	for i, row := range box {
+		l := lines[top+i]
+		lines[top+i] = cut(l, 0, left) + row + cut(l, left+boxWidth, frameWidth)
+	}
+	return lines
+}
+
+// cut returns the columns from start up to end of the plain text, padded with spaces where it is shorter (or a wide
+// character is split)
+func cut(text string, start int, end int) string {
+	var sb strings.Builder
+	column := 0
+	for _, r := range text {
+		w := runeWidth(r)
+		if column >= start && column+w <= end {
+			sb.WriteRune(r)
+		} else if column < end && column+w > start {
+			from, to := column, column+w
+			if from < start {
+				from = start
+			}
+			if to > end {
+				to = end
+			}
+			sb.WriteString(strings.Repeat(" ", to-from))
+		}
+		column += w
+		if column >= end {
+			break
+		}
+	}
+	if column < start {
+		column = start
+	}
+	if column < end {
+		sb.WriteString(strings.Repeat(" ", end-column))
+	}
+	return sb.String()
+}
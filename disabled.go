@@ -0,0 +1,18 @@
+package gomenutree
+
+// DisableOption will disable the named option: it is drawn dimmed, and choosing it shows the reason instead of
+// executing (MenuTree.SkipDisabled makes the selection cursor skip it entirely)
+func (m *Menu) DisableOption(name string, reason string) {
+	if o, ok := m.options[name]; ok {
+		o.disabled = true
+		o.reason = reason
+	}
+}
+
+// EnableOption will re-enable a disabled option
+func (m *Menu) EnableOption(name string) {
+	if o, ok := m.options[name]; ok {
+		o.disabled = false
+		o.reason = ""
+	}
+}
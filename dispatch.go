@@ -0,0 +1,38 @@
+package gomenutree
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// invoke runs the option the way its kind needs, limited by its timeout (see timed): with the arguments resolved into
+// call (from the prompt or command line, nil if it takes none), as a command reading in (nil for no input), an async
+// function waited for, or a progress function drawing its progress when draw is set (otherwise given a Progress
+// nobody watches, progress options not being limited); every way of running an option (the menu, Run, favorites,
+// macros and remote execution) goes through it
+func (m *MenuTree) invoke(name string, o *option, call func() error, in io.Reader, draw bool) error {
+	switch {
+	case call != nil:
+		return m.timed(name, o, func(context.Context) error {
+			return call()
+		})
+	case o.command != nil:
+		return m.timed(name, o, func(ctx context.Context) error {
+			return m.runCommand(ctx, o.command, in)
+		})
+	case o.asyncFunction != nil:
+		return m.timed(name, o, func(context.Context) error {
+			return o.asyncFunction()
+		})
+	case o.progressFunction != nil && draw:
+		for _, l := range m.runWithProgress(o.progressFunction) {
+			fmt.Fprintln(m.Writer(), l)
+		}
+		return nil
+	case o.progressFunction != nil:
+		o.progressFunction(&Progress{percent: -1})
+		return nil
+	}
+	return m.timed(name, o, o.handler())
+}
@@ -0,0 +1,60 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ttacon/chalk"
+)
+
+// AddEnvironment will define an environment (e.g. "dev", "staging", "prod") and the theme menus are drawn with while
+// it is set, overriding the tree's theme and menu styles where fields are not nil (e.g. Frame: chalk.Red.Color for a
+// red border in prod); the theme's Frame also colors the environment banner
+func (m *MenuTree) AddEnvironment(name string, theme Style) {
+	m.bgMu.Lock()
+	defer m.bgMu.Unlock()
+	if m.environments == nil {
+		m.environments = make(map[string]Style)
+	}
+	m.environments[name] = theme
+}
+
+// SetEnvironment will switch to the named environment (see AddEnvironment), shown as a banner in the menu header and
+// drawn with its theme ("" clears it); safe to call at runtime and from other goroutines, the menu is redrawn right
+// away while waiting for input
+func (m *MenuTree) SetEnvironment(name string) error {
+	m.bgMu.Lock()
+	if _, ok := m.environments[name]; !ok && name != "" {
+		m.bgMu.Unlock()
+		return fmt.Errorf("gomenutree: environment %q not defined", name)
+	}
+	m.environment = name
+	m.bgMu.Unlock()
+	m.refresh()
+	return nil
+}
+
+// Environment will return the name of the current environment ("" if none is set)
+func (m *MenuTree) Environment() string {
+	m.bgMu.Lock()
+	defer m.bgMu.Unlock()
+	return m.environment
+}
+
+// environmentTheme returns the current environment's name and theme
+func (m *MenuTree) environmentTheme() (string, Style) {
+	m.bgMu.Lock()
+	defer m.bgMu.Unlock()
+	return m.environment, m.environments[m.environment]
+}
+
+// environmentBanner returns the banner of the current environment (in reverse video and its theme's frame color, ""
+// if none is set) and the menu style merged with its theme
+func (m *MenuTree) environmentBanner(menuStyle Style) (string, Style) {
+	env, theme := m.environmentTheme()
+	if env == "" {
+		return "", menuStyle
+	}
+	banner := fmt.Sprintf(m.Strings.Environment, strings.ToUpper(sanitize(env)))
+	return apply(compose(theme.Frame, chalk.Inverse.TextStyle), banner), theme.merge(menuStyle)
+}
@@ -0,0 +1,35 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OnExit will register a cleanup hook run (in registration order) when the user exits;
+// if a hook returns an error the exit is cancelled and the error shown
+func (m *MenuTree) OnExit(hook func() error) {
+	m.exitHooks = append(m.exitHooks, hook)
+}
+
+// exitAllowed asks for confirmation (if configured) and runs the exit hooks, reporting whether the menu may exit
+func (m *MenuTree) exitAllowed() bool {
+	if m.ConfirmExit && !m.hosted {
+		fmt.Fprintln(m.out, "\n"+m.Strings.ConfirmExit)
+		m.state(m.currentMenu).lastRenderLines += 2
+		if answer := strings.ToUpper(m.getInput()); answer != "Y" && answer != "EXIT" {
+			m.render()
+			return false
+		}
+	}
+	for _, hook := range m.exitHooks {
+		if e := hook(); e != nil {
+			fmt.Fprintln(m.out, "\n"+fmt.Sprintf(m.Strings.ExitCancelled, e))
+			fmt.Fprintln(m.out, m.continuePrompt())
+			m.state(m.currentMenu).lastRenderLines += 2
+			m.getInput()
+			m.render()
+			return false
+		}
+	}
+	return true
+}
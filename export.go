@@ -0,0 +1,92 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Export will return the structure of the tree (menus, prompts, options and submenus) starting at the home menu,
+// in the same form used by NewMenuTreeFromDefinition; option actions are left empty since functions have no names, separators are skipped,
+// and a submenu that is already on the current path (a cycle) is exported by name only
+func (m *MenuTree) Export() MenuDefinition {
+	return m.exportMenu(m.homeMenu, map[*Menu]bool{})
+}
+
+// exportMenu recursively exports a menu, using onPath to break submenu cycles
+func (m *MenuTree) exportMenu(menu *Menu, onPath map[*Menu]bool) MenuDefinition {
+	if onPath[menu] {
+		return MenuDefinition{Name: menu.name}
+	}
+	def := MenuDefinition{Name: menu.name, Prompt: menu.prompt}
+	if menu.promptFunction != nil {
+		def.Prompt = menu.promptFunction()
+	}
+	for _, o := range menu.optionsOrder {
+		if menu.options[o].separator || menu.options[o].subMenu != nil {
+			continue
+		}
+		def.Options = append(def.Options, OptionDefinition{Name: o})
+	}
+	onPath[menu] = true
+	for _, sm := range m.children(menu) {
+		def.SubMenus = append(def.SubMenus, m.exportMenu(sm, onPath))
+	}
+	delete(onPath, menu)
+	return def
+}
+
+// Tree will return an ASCII tree of the definition (submenus are suffixed with "/")
+func (d MenuDefinition) Tree() string {
+	var sb strings.Builder
+	sb.WriteString(d.Name + "/\n")
+	d.writeTree(&sb, "")
+	return sb.String()
+}
+
+// writeTree writes the options and submenus of the definition below the given indentation prefix
+func (d MenuDefinition) writeTree(sb *strings.Builder, prefix string) {
+	total := len(d.Options) + len(d.SubMenus)
+	for i, o := range d.Options {
+		branch := "|-- "
+		if i == total-1 {
+			branch = "`-- "
+		}
+		sb.WriteString(prefix + branch + o.Name + "\n")
+	}
+	for i, sm := range d.SubMenus {
+		branch, indent := "|-- ", "|   "
+		if len(d.Options)+i == total-1 {
+			branch, indent = "`-- ", "    "
+		}
+		sb.WriteString(prefix + branch + sm.Name + "/\n")
+		sm.writeTree(sb, prefix+indent)
+	}
+}
+
+// DOT will return the definition as a Graphviz digraph (menus as folders, options as boxes)
+func (d MenuDefinition) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph gomenutree {\n")
+	next := 0
+	d.writeDOT(&sb, &next)
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// writeDOT writes the nodes and edges for the definition, returning the node id of the menu itself
+func (d MenuDefinition) writeDOT(sb *strings.Builder, next *int) string {
+	id := fmt.Sprintf("n%d", *next)
+	*next++
+	sb.WriteString(fmt.Sprintf("  %s [label=%q shape=folder];\n", id, d.Name))
+	for _, o := range d.Options {
+		oID := fmt.Sprintf("n%d", *next)
+		*next++
+		sb.WriteString(fmt.Sprintf("  %s [label=%q shape=box];\n", oID, o.Name))
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", id, oID))
+	}
+	for _, sm := range d.SubMenus {
+		smID := sm.writeDOT(sb, next)
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", id, smID))
+	}
+	return id
+}
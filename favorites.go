@@ -0,0 +1,205 @@
+package gomenutree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type (
+	// FavoriteStore keeps the favorite options (as "/" separated paths from the home menu) between runs
+	FavoriteStore interface {
+		Load() ([]string, error)
+		Save(paths []string) error
+	}
+
+	// favoritesFile is a FavoriteStore keeping the paths in a JSON file
+	favoritesFile string
+
+	// favorites holds the favorite options and the menu listing them
+	favorites struct {
+		key   string
+		store FavoriteStore
+		menu  *Menu
+		paths []string
+	}
+)
+
+// FavoritesFile will return a FavoriteStore keeping the favorites in a JSON file (a missing file means none)
+func FavoritesFile(path string) FavoriteStore {
+	return favoritesFile(path)
+}
+
+// Load implements FavoriteStore
+func (f favoritesFile) Load() ([]string, error) {
+	b, e := os.ReadFile(string(f))
+	if errors.Is(e, os.ErrNotExist) {
+		return nil, nil
+	} else if e != nil {
+		return nil, e
+	}
+	var paths []string
+	return paths, json.Unmarshal(b, &paths)
+}
+
+// Save implements FavoriteStore
+func (f favoritesFile) Save(paths []string) error {
+	b, e := json.Marshal(paths)
+	if e != nil {
+		return e
+	}
+	return os.WriteFile(string(f), b, 0o600)
+}
+
+// EnableFavorites will let the user press key to add the highlighted option to (or remove it from) a favorites menu
+// listed first in the home menu while it has entries; favorites are loaded from and saved to the store (nil keeps
+// them for this run only)
+func (m *MenuTree) EnableFavorites(key string, store FavoriteStore) error {
+	f := &favorites{key: strings.ToUpper(key), store: store, menu: NewMenu(m.Strings.Favorites, "", nil)}
+	if store != nil {
+		paths, e := store.Load()
+		if e != nil {
+			return fmt.Errorf("gomenutree: loading favorites: %w", e)
+		}
+		f.paths = paths
+	}
+	m.favorites = f
+	m.updateFavorites()
+	return nil
+}
+
+// Favorites will return the paths of the favorite options
+func (m *MenuTree) Favorites() []string {
+	if m.favorites == nil {
+		return nil
+	}
+	return append([]string(nil), m.favorites.paths...)
+}
+
+// toggleFavorite adds the highlighted option to the favorites, or removes it if it is one (or is in the favorites menu)
+func (m *MenuTree) toggleFavorite() {
+	f := m.favorites
+	state := m.state(m.currentMenu)
+	if state.selection < 0 || state.selection >= len(m.currentMenu.optionsOrder) {
+		return
+	}
+	name := m.currentMenu.optionsOrder[state.selection]
+	path := name
+	if m.currentMenu != f.menu {
+		if o := m.currentMenu.options[name]; !chainable(o) {
+			return
+		}
+		path = strings.Join(append(m.menuPath(m.currentMenu), name), "/")
+	}
+	removed := false
+	for i, p := range f.paths {
+		if p == path {
+			f.paths = append(f.paths[:i], f.paths[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		f.paths = append(f.paths, path)
+	}
+	m.updateFavorites()
+	if i := entryIndex(m, m.currentMenu, name); i >= 0 {
+		state.selection = i
+	} else if state.selection >= m.entryCount() {
+		state.selection = m.entryCount() - 1
+	}
+}
+
+// updateFavorites rebuilds the favorites menu, lists it in the home menu only while it has entries and saves the
+// favorites to the store
+func (m *MenuTree) updateFavorites() {
+	f := m.favorites
+	m.syncPathMenu(f.menu, f.paths, 0)
+	if f.store != nil {
+		if e := f.store.Save(f.paths); e != nil {
+			m.debug("favorites not saved", "error", e)
+		}
+	}
+}
+
+// favoriteNames returns the names of the current menu's options that are favorites (marked when drawn)
+func (m *MenuTree) favoriteNames() map[string]bool {
+	if m.favorites == nil || m.currentMenu == m.favorites.menu {
+		return nil
+	}
+	names := make(map[string]bool)
+	prefix := strings.Join(m.menuPath(m.currentMenu), "/") + "/"
+	for _, p := range m.favorites.paths {
+		if name := strings.TrimPrefix(p, prefix); name != p && !strings.Contains(name, "/") {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// runPath runs the option at the path (an entry of the favorites or recent menu) as if it were chosen in its menu: the
+// menus leading to it must be visible and not protected, and the option visible, enabled, able to run as a macro step
+// (see chainable) and not blocked by its policy; it is audited and limited by its timeout
+func (m *MenuTree) runPath(path string) {
+	menu, name := m.homeMenu, path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		var e error
+		if menu, _, e = m.openPath(path[:i]); e != nil {
+			fmt.Fprintln(m.Writer(), m.Strings.FunctionNotFound)
+			return
+		}
+		name = path[i+1:]
+	}
+	o, ok := menu.options[name]
+	if index := m.optionNamed(menu, name); !ok || index < 0 || menu.optionsOrder[index] != name || !chainable(o) {
+		fmt.Fprintln(m.Writer(), m.Strings.FunctionNotFound)
+		return
+	}
+	if o.disabled {
+		fmt.Fprintln(m.Writer(), fmt.Sprintf(m.Strings.Disabled, name, o.reason))
+		return
+	}
+	if reason := m.blockReason(name, o); reason != "" {
+		fmt.Fprintln(m.Writer(), reason)
+		return
+	}
+	m.reportFailure(name, o, m.audited(menu, name, func() error {
+		return m.invoke(name, o, nil, os.Stdin, true)
+	}))
+}
+
+// copyFavorites gives the tree (a session or clone of this one, see configured) favorites of its own, starting as a
+// copy of these, listed in its home menu in place of this tree's favorites menu
+func (m *MenuTree) copyFavorites(c *MenuTree) {
+	if m.favorites == nil {
+		return
+	}
+	f := *m.favorites
+	f.menu = NewMenu(m.favorites.menu.name, "", nil)
+	f.paths = append([]string(nil), f.paths...)
+	c.favorites = &f
+	c.homeMenu.DeleteOption(m.favorites.menu.name)
+	c.syncPathMenu(f.menu, f.paths, 0)
+}
+
+// syncPathMenu fills the menu with an option running each of the option paths, listing it at the position in the home
+// menu while it has entries
+func (m *MenuTree) syncPathMenu(menu *Menu, paths []string, position int) {
+	for _, name := range append([]string(nil), menu.optionsOrder...) {
+		menu.DeleteOption(name)
+	}
+	for _, path := range paths {
+		path := path
+		menu.AddOption(path, func() {
+			m.runPath(path)
+		})
+	}
+	if len(paths) == 0 {
+		m.homeMenu.DeleteOption(menu.name)
+	} else if _, ok := m.homeMenu.options[menu.name]; !ok {
+		m.AddSubMenuOption(m.homeMenu, menu)
+		m.homeMenu.MoveOption(menu.name, position)
+	}
+}
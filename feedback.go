@@ -0,0 +1,63 @@
+package gomenutree
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ttacon/chalk"
+)
+
+// Feedback selects how the menu signals a key it can not act on (see MenuTree.InvalidKey)
+type Feedback int
+
+const (
+	// FeedbackNone ignores the key
+	FeedbackNone Feedback = iota
+	// FeedbackBell rings the terminal bell
+	FeedbackBell
+	// FeedbackFlash draws the frame in FlashStyle for FlashDuration
+	FeedbackFlash
+	// FeedbackBellAndFlash rings the bell and flashes the frame
+	FeedbackBellAndFlash
+)
+
+// String will return the name of the feedback
+func (f Feedback) String() string {
+	switch f {
+	case FeedbackNone:
+		return "none"
+	case FeedbackBell:
+		return "bell"
+	case FeedbackFlash:
+		return "flash"
+	case FeedbackBellAndFlash:
+		return "bell and flash"
+	}
+	return "unknown"
+}
+
+// invalidKey signals a key that maps to nothing (or going back from the home menu) as configured by InvalidKey
+func (m *MenuTree) invalidKey(input string) {
+	if m.InvalidKey == FeedbackNone || m.hosted {
+		return
+	}
+	m.debug("invalid key", "key", input, "feedback", m.InvalidKey.String())
+	if m.InvalidKey == FeedbackBell || m.InvalidKey == FeedbackBellAndFlash {
+		fmt.Fprint(m.out, "\a")
+	}
+	if (m.InvalidKey == FeedbackFlash || m.InvalidKey == FeedbackBellAndFlash) && m.ownsTerminal() && m.Redraw {
+		m.flashing = true
+		m.render()
+		time.Sleep(m.FlashDuration)
+		m.flashing = false
+		m.render()
+	}
+}
+
+// flashStyle returns the frame style used while flashing
+func (m *MenuTree) flashStyle() func(string) string {
+	if m.FlashStyle != nil {
+		return m.FlashStyle
+	}
+	return chalk.Inverse.TextStyle
+}
@@ -0,0 +1,159 @@
+package gomenutree
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// Gate protects a menu or option behind a masked passphrase prompt (see Menu.Protect and Menu.ProtectOption)
+type Gate struct {
+	Prompt   string                   //masked prompt, "" uses Strings.Passphrase
+	Check    func(secret string) bool //reports whether the typed secret grants access (e.g. Passphrase, or a call to an auth service)
+	Attempts int                      //tries before access is denied (0 allows a single try)
+}
+
+// Passphrase will return a Gate check accepting only the given passphrase (compared in constant time)
+func Passphrase(passphrase string) func(secret string) bool {
+	return func(secret string) bool {
+		return subtle.ConstantTimeCompare([]byte(secret), []byte(passphrase)) == 1
+	}
+}
+
+// Protect will require the gate to be passed every time the menu is entered from its parent
+// (ChangeMenu and going back into it are not checked)
+func (m *Menu) Protect(gate Gate) {
+	m.gate = &gate
+}
+
+// ProtectOption will require the gate to be passed every time the named option is chosen
+func (m *Menu) ProtectOption(name string, gate Gate) {
+	if o, ok := m.options[name]; ok {
+		o.gate = &gate
+	}
+}
+
+// Unprotect will remove the menu's gate
+func (m *Menu) Unprotect() {
+	m.gate = nil
+}
+
+// UnprotectOption will remove the named option's gate
+func (m *Menu) UnprotectOption(name string) {
+	if o, ok := m.options[name]; ok {
+		o.gate = nil
+	}
+}
+
+// authorize asks for the gate's secret until it is accepted or the attempts run out, in which case access is denied
+// with a message; a hosted menu tree can not prompt, so access is always denied
+func (m *MenuTree) authorize(name string, gate *Gate) bool {
+	if gate.Check == nil {
+		return true
+	}
+	prompt := gate.Prompt
+	if prompt == "" {
+		prompt = m.Strings.Passphrase
+	}
+	attempts := gate.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	fmt.Fprintln(m.out)
+	lines := 1
+	for attempt := 1; attempt <= attempts; attempt++ {
+		secret, ok := m.ReadSecret(prompt)
+		lines++
+		if !ok {
+			break
+		}
+		if gate.Check(secret) {
+			m.debug("access granted", "entry", name, "attempt", attempt)
+			return true
+		}
+		if attempt < attempts {
+			fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.WrongPassphrase, attempts-attempt))
+			lines++
+		}
+	}
+	m.debug("access denied", "entry", name)
+	if m.hosted {
+		return false
+	}
+	fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.AccessDenied, name))
+	fmt.Fprintln(m.out, m.continuePrompt())
+	m.state(m.currentMenu).lastRenderLines += lines + 2
+	m.getInput()
+	m.render()
+	return false
+}
+
+// ReadSecret will print the prompt and read a line of text typed by the user without echoing it (a "*" is shown
+// for each character), reporting false if the prompt was cancelled with Esc or Ctrl+C; like ReadLine, the next event
+// from an input function (see SetInputFunc) is used as the whole line, and secrets are never recorded; it is masked
+// whenever a terminal is there to read from, even in line mode
+func (m *MenuTree) ReadSecret(prompt string) (string, bool) {
+	fmt.Fprint(m.out, prompt)
+	switch {
+	case m.hosted:
+		fmt.Fprintln(m.out)
+		return "", false
+	case m.inputFunc != nil:
+		line, e := m.inputEvents().next(0)
+		fmt.Fprintln(m.out, strings.Repeat("*", len([]rune(line))))
+		return line, e == nil
+	case m.in != nil:
+		line, e := m.readLineFrom(true, 0)
+		return line, e == nil
+	case m.lineMode && (!interactive() || (m.lines != nil && m.lines.pending != nil)):
+		line, e := m.readLineInput(0) //no terminal to mask it on, or a line read already waiting would take the keys
+		return line, e == nil
+	case m.lineMode: //LineMode forced on a terminal, which is handed back in its normal mode once the secret is read
+		defer m.releaseTTY()
+	}
+	return m.readSecret()
+}
+
+// readSecret reads a masked line from the terminal in raw mode, handling backspace
+func (m *MenuTree) readSecret() (string, bool) {
+	tty, done, tErr := m.rawTTY()
+	if tErr != nil {
+		return "", false
+	}
+	defer done()
+	var secret []rune
+	for {
+		key, e := m.nextKey(tty)
+		if e != nil {
+			fmt.Fprint(m.out, "\r\n")
+			return "", false
+		}
+		if text, ok := pasted(key); ok {
+			text = strings.TrimRight(text, "\r\n")
+			secret = append(secret, []rune(text)...)
+			fmt.Fprint(m.out, strings.Repeat("*", len([]rune(text))))
+			continue
+		}
+		if len(key) == 0 || (key[0] == escape && len(key) > 1) { //ignore arrow and other escape sequences
+			continue
+		}
+		switch key[0] {
+		case enter, '\n':
+			fmt.Fprint(m.out, "\r\n")
+			return string(secret), true
+		case escape, ctrlC:
+			fmt.Fprint(m.out, "\r\n")
+			return "", false
+		case 127, 8: //backspace, delete
+			if len(secret) > 0 {
+				secret = secret[:len(secret)-1]
+				fmt.Fprint(m.out, "\b \b")
+			}
+		default:
+			if key[0] >= ' ' {
+				secret = append(secret, []rune(string(key))...)
+				fmt.Fprint(m.out, strings.Repeat("*", len([]rune(string(key)))))
+			}
+		}
+	}
+}
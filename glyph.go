@@ -0,0 +1,48 @@
+package gomenutree
+
+// SetOptionGlyph will set an icon (e.g. an emoji or nerd font glyph) drawn before the named option's label
+func (m *Menu) SetOptionGlyph(name string, glyph string) {
+	if o, ok := m.options[name]; ok {
+		o.glyph = glyph
+	}
+}
+
+// SetOptionBadge will set a badge drawn after the named option's label (e.g. a count like "(12)")
+// badge and badgeFunction are mutually exclusive with badgeFunction (evaluated on render) taking priority if not nil
+func (m *Menu) SetOptionBadge(name string, badge string, badgeFunction func() string) {
+	if o, ok := m.options[name]; ok {
+		o.badge = badge
+		o.badgeFunc = badgeFunction
+	}
+}
+
+// SetGlyph will set an icon drawn before this menu's name where it is listed as a submenu
+func (m *Menu) SetGlyph(glyph string) {
+	m.glyph = glyph
+}
+
+// SetBadge will set a badge drawn after this menu's name where it is listed as a submenu
+// badge and badgeFunction are mutually exclusive with badgeFunction (evaluated on render) taking priority if not nil
+func (m *Menu) SetBadge(badge string, badgeFunction func() string) {
+	m.badge = badge
+	m.badgeFunc = badgeFunction
+}
+
+// evaluate returns the result of the function if not nil, otherwise the static text
+func evaluate(text string, function func() string) string {
+	if function != nil {
+		return function()
+	}
+	return text
+}
+
+// decorate surrounds the label with its glyph and badge, if set
+func decorate(glyph string, label string, badge string) string {
+	if glyph != "" {
+		label = glyph + " " + label
+	}
+	if badge != "" {
+		label += " " + badge
+	}
+	return label
+}
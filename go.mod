@@ -1,9 +1,9 @@
 module github.com/mikefrom1974/gomenutree
 
-go 1.17
+go 1.18
 
 require (
-	github.com/pkg/term v1.1.0 // indirect
-	github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31 // indirect
-	golang.org/x/sys v0.0.0-20200909081042-eff7692f9009 // indirect
+	github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31
+	golang.org/x/sys v0.7.0
+	golang.org/x/term v0.6.0
 )
@@ -1,10 +1,18 @@
 package gomenutree
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/pkg/term"
 	"github.com/ttacon/chalk"
 )
 
@@ -16,26 +24,211 @@ type (
 		previousMenu *Menu
 		subMenuMap   map[*Menu][]*Menu
 		displaying   bool
+		inputFunc    func() string
+		recording    *Recording
+		recordStart  time.Time
+		inputErr     error
+		exitReason   ExitReason
+		result       interface{}
+		stopped      bool
+		in           io.Reader
+		out          io.Writer
+		width        int
+		height       int
+		sizeSet      bool
+		lineMode     bool
+		hosted       bool
+		hostOut      *bytes.Buffer
+		region       *region
+		lastOutput   []string
+		debugFunc    func(msg string, args ...interface{})
+		idleTimeout  time.Duration
+		idleAction   IdleAction
+		events       *queuedReads
+		chunks       *queuedReads
+		lines        *queuedReads
+		countdownEnd time.Time
+		keys         keyDecoder
+		tty          *ttyFile
+		suspended    bool
+		inOption     bool
+		keyBindings  map[string]func()
+		deferRender  bool
+		renderDue    bool
+		lastRender   time.Time
+		mu           sync.Mutex
+		idle         bool
+		bgMu         sync.Mutex //guards state updated from background goroutines
+		status       string
+		statusFunc   func() string
+		statusShown  bool
+		footerRows   int
+		revealKeys   []string
+		keyHistory   []string
+		revealed     bool
+		exitHooks    []func() error
+		visibleFunc  func(entry Metadata) bool
+		states       map[*Menu]*menuState
+		runs         *optionRuns //run and cooldown state of the options, shared by the tree's sessions
+		macro        *macroRecording
+		favorites    *favorites
+		stateStore   StateStore
+		savedState   *UIState //state loaded by SetStateStore, restored once Display (or Host) starts
+		environments map[string]Style
+		environment  string
+		plugins      []registeredPlugin
+		title        string
+		flashing     bool
+		spokenMenu   *Menu
+		spokenIndex  int
+		spokenNotice string
+		typed        string
+		beneath      *Menu
+		beneathPrev  *Menu
+		last         *lastAction
+		recovery     *recovery
+		lastInput    []byte
+		usageStats   *usage
+		searchKey    string
+		searchMenu   *Menu
+		loadMenu     *Menu
+		notice       *notification
+		output       *outputArea
+		outMu        sync.Mutex //guards optionOut, set while option output is captured
+		optionOut    io.Writer
+		session      bool            //whether the tree is a session's (see NewSession), which never captures stdout
+		copies       map[*Menu]*Menu //session's copy of each of the tree's menus
 
-		Redraw bool //whether to back up and redraw the menu in place
+		Redraw bool  //whether to back up and redraw the menu in place
+		Pager  bool  //whether to capture the output of every option and show it in the built-in pager
+		Theme  Style //default styling for every menu (overridden by menu and option styles)
+
+		Strings Strings //every built-in message, for translation
+
+		SkipDisabled    bool //whether the selection cursor skips over disabled options
+		StickySelection bool //whether returning to a menu restores its previous selection instead of the default (off by default)
+
+		ExitLabel   string //footer text for the exit key (the first "x" is underlined, "(x)" is appended if there is none)
+		HideExit    bool   //whether to leave the exit key out of the footer (it still works)
+		ConfirmExit bool   //whether to ask for confirmation before exiting
+
+		PreviewWidth int //maximum width of the preview pane (shown when the current menu has previews)
+		Columns      int //maximum number of option columns (menus may override), fewer if the terminal is too narrow
+
+		Border        *Border //box drawn around each menu (nil for the classic asterisk frame)
+		Padding       int     //spaces between the box sides and the menu text
+		TitleInBorder bool    //whether the menu name is set into the top border instead of its own line
+
+		Overflow Overflow //how lines wider than the terminal are handled (truncated with an ellipsis by default)
+		LineMode bool     //force the numbered line-mode menu (used automatically when stdin is piped or there is no terminal)
+
+		Audit   AuditSink //receives a record of every option run (nil disables auditing)
+		Metrics Metrics   //counts the options chosen and times their runs (nil disables metrics)
+
+		CopyKey  string                  //key copying the last option's output to the clipboard at the continue prompt ("" disables)
+		CopyFunc func(text string) error //copies text to the clipboard, nil uses a platform tool or the OSC 52 sequence
+
+		SubMenuMarker  string //drawn after submenus listed among the options (see AddSubMenuOption)
+		NavigationRows bool   //whether back and exit are also listed as selectable rows below the entries
+
+		Pause      PauseMode     //what happens after an option's output (options may override with SetOptionPause)
+		AutoReturn time.Duration //how long PauseTimed waits before returning to the menu
+
+		// BeforeExecuteBanner replaces the "Executing" banner and "Output" rule printed before an option's output
+		// ("" prints nothing, nil keeps the default)
+		BeforeExecuteBanner func(option string) string
+		// AfterExecuteBanner replaces the "End" rule printed after an option's output, given how long it ran
+		// ("" prints nothing, nil keeps the default)
+		AfterExecuteBanner func(option string, elapsed time.Duration) string
+
+		SearchExecutes bool //whether choosing a search result runs the entry instead of just highlighting it
+
+		RenderInterval time.Duration //minimum time between renders while navigation keys arrive in a burst (0 renders every key)
+
+		OutputLines int //rows the lines written to OutputWriter scroll within while an option runs (0 writes them as they come)
+
+		Serialize bool //whether options are kept from running while an async option is still running
+
+		IndentDepth   int  //spaces the frame is indented by per submenu level, showing how deep the menu is (0 disables)
+		TerminalTitle bool //whether the terminal window title shows the path to the current menu (restored on exit)
+
+		InvalidKey    Feedback            //how a key mapping to nothing (or going back from the home menu) is signalled
+		FlashStyle    func(string) string //frame style while flashing (nil uses reverse video)
+		FlashDuration time.Duration       //how long the frame flashes
+
+		SerialConsole bool          //whether to draw for a slow serial link: no escape sequences, no redraw in place and the frame kept within 80x24
+		LineDelay     time.Duration //pause after each line written on a serial console (for devices that can not keep up)
+
+		ShowLastAction bool   //whether the footer shows the option that last ran and how long ago, dimmed
+		UndoKey        string //key undoing the last action after confirmation, if it registered an undo function (see Menu.SetUndo, "" disables)
+
+		Accessible bool //whether menus are written as plain appended lines for screen readers and braille terminals (no cursor movement or borders, selection changes announced, entries chosen by typed number)
 	}
 
 	// Menu struct holds the map of options to functions, as well as configuration
 	Menu struct {
-		name            string
-		prompt          string
-		promptFunction  func() string
-		options         map[string]func()
-		optionsOrder    []string
-		selection       int
-		hotKeys         map[string]int
-		lastRenderLines int
-		longestLine     int
+		name           string
+		prompt         string
+		promptFunction func() string
+		options        map[string]*option
+		optionsOrder   []string
+		style          Style
+		glyph          string
+		badge          string
+		badgeFunc      func() string
+		separators     int
+		defaultIndex   int
+		defaultName    string
+		autoRun        string
+		autoRunAfter   time.Duration
+		tableHeader    []string
+		onEnter        func(ctx context.Context) error
+		gate           *Gate
+		roles          []string
+		preview        func() string
+		columns        int
+		sessionCopy    func() *Menu //copies the menu for a session (see NewSession), nil for a plain copy
+		source         *Menu        //menu a session's plain copy was made from, refreshed from it after loading
+	}
+
+	// option holds a menu option's function along with its per-option configuration
+	option struct {
+		function         func()
+		progressFunction func(progress *Progress)
+		asyncFunction    func() error
+		command          *command
+		args             *argSpec
+		gate             *Gate
+		contextFunction  func(ctx context.Context) error
+		confirmPhrase    string
+		cooldown         time.Duration
+		undo             func() error
+		timeout          time.Duration
+		roles            []string
+		subMenu          *Menu
+		jump             func()
+		pause            *PauseMode
+		pauseDelay       time.Duration
+		separator        bool
+		label            string
+		disabled         bool
+		reason           string
+		hidden           bool
+		hotKey           string
+		preview          func() string
+		labelFunc        func() string
+		style            Style
+		glyph            string
+		badge            string
+		badgeFunc        func() string
+		pager            bool
+		description      string
+		cells            []string
 	}
 )
 
 const (
-	up       byte = 65 // arrow keys are the last byte of a 3 byte sequence
+	up       byte = 65 // arrow keys are the last byte of an escape sequence
 	down     byte = 66
 	left     byte = 68
 	right    byte = 67
@@ -44,10 +237,12 @@ const (
 	backtick byte = 96
 	exitX    byte = 120
 	ctrlC    byte = 3
+	ctrlZ    byte = 26
 
-	upDownArrow = '\u2195'
-	leftArrow   = '\u2190'
-	rightArrow  = '\u2192'
+	upDownArrow    = '\u2195'
+	leftArrow      = '\u2190'
+	rightArrow     = '\u2192'
+	favoriteMarker = '\u2605'
 )
 
 // NewMenuTree will create and return a new go menu tree. This will be the main object used by the user.
@@ -57,6 +252,20 @@ func NewMenuTree(homeMenu *Menu) *MenuTree {
 	m.currentMenu = homeMenu
 	m.Redraw = true
 	m.subMenuMap = make(map[*Menu][]*Menu)
+	m.runs = &optionRuns{async: make(map[*option]*asyncStatus), cooldowns: make(map[*option]time.Time)}
+	m.out = os.Stdout
+	m.Theme = DefaultTheme()
+	m.Strings = DefaultStrings()
+	m.ExitLabel = "Exit"
+	m.PreviewWidth = 40
+	m.Columns = 1
+	m.Padding = 1
+	m.SubMenuMarker = string('\u25b8')
+	m.RenderInterval = 30 * time.Millisecond
+	m.OutputLines = 10
+	m.FlashDuration = 150 * time.Millisecond
+	m.UndoKey = "u"
+	m.output = new(outputArea)
 	return m
 }
 
@@ -73,7 +282,7 @@ func NewMenu(name string, prompt string, promptFunction func() string) *Menu {
 		m.prompt = prompt
 		m.promptFunction = nil
 	}
-	m.options = make(map[string]func())
+	m.options = make(map[string]*option)
 	return m
 }
 
@@ -82,9 +291,9 @@ func (m *MenuTree) Name() string {
 	return m.currentMenu.name
 }
 
-// Prompt will return the prompt for the current menu
+// Prompt will return the prompt for the current menu (calling the prompt function, if set)
 func (m *MenuTree) Prompt() string {
-	return m.currentMenu.prompt
+	return evaluate(m.currentMenu.prompt, m.currentMenu.promptFunction)
 }
 
 // SetPrompt will set a static text prompt, or a function to generate the prompt on render, for the current menu
@@ -102,7 +311,18 @@ func (m *MenuTree) SetPrompt(prompt string, promptFunction func() string) {
 
 // AddOption will add a named option to the list of menu selections, mapped to a function
 func (m *Menu) AddOption(name string, function func()) {
-	m.options[name] = function
+	m.addOption(name, &option{function: function})
+}
+
+// AddDynamicOption will add an option whose label is generated by labelFunction on every render
+// (e.g. "Maintenance mode (currently ON)"); name stays the key for the other option methods
+func (m *Menu) AddDynamicOption(name string, labelFunction func() string, function func()) {
+	m.addOption(name, &option{function: function, labelFunc: labelFunction})
+}
+
+// addOption will add (or replace and move to the end) the named option
+func (m *Menu) addOption(name string, o *option) {
+	m.options[name] = o
 	for i, n := range m.optionsOrder {
 		if n == name {
 			m.optionsOrder = append(m.optionsOrder[:i], m.optionsOrder[i+1:]...)
@@ -153,231 +373,626 @@ func (m *MenuTree) DeleteSubMenu(parentMenu *Menu, childMenu *Menu) {
 
 // ChangeMenu will jump straight to the given menu, setting the current menu to the "back" action result
 func (m *MenuTree) ChangeMenu(menu *Menu) {
-	m.previousMenu = m.currentMenu
+	if c, ok := m.copies[menu]; ok { //a session is given the tree's menus, it shows its own copies
+		menu = c
+	}
+	m.changeMenu(menu, m.currentMenu)
+}
+
+// changeMenu will switch to the given menu, with previous as the "back" action result
+func (m *MenuTree) changeMenu(menu *Menu, previous *Menu) {
+	m.debug("menu changed", "from", m.currentMenu.name, "to", menu.name)
+	m.previousMenu = previous
 	if menu == m.homeMenu {
 		m.previousMenu = nil
 	}
+	menu = m.enterLoaded(menu, m.previousMenu)
 	m.currentMenu = menu
-	m.currentMenu.lastRenderLines = 0
+	m.state(m.currentMenu).lastRenderLines = 0
+	m.initSelection()
 	if m.displaying {
+		m.startCountdown()
 		m.render()
 	}
 }
 
 // render will draw the current menu, optionally redrawing (erasing and writing over itself)
 func (m *MenuTree) render() {
-	if m.currentMenu.lastRenderLines > 0 && m.Redraw {
-		fmt.Printf("\033[%dA", m.currentMenu.lastRenderLines)
+	if m.hosted {
+		return
+	}
+	if m.deferRender {
+		m.renderDue = true
+		return
+	}
+	m.renderDue, m.lastRender = false, time.Now()
+	m.updateTitle()
+	if m.region != nil {
+		m.drawRegion()
+		return
+	}
+	state := m.state(m.currentMenu)
+	if m.lineMode {
+		fmt.Fprint(m.out, m.lineFrame())
+		state.lastRenderLines = 0
+		return
+	}
+	if m.Accessible {
+		m.announce()
+		return
+	}
+	moved := 0
+	if state.lastRenderLines > 0 && m.Redraw {
+		moved = state.lastRenderLines
+		fmt.Fprintf(m.out, "\033[%dA", moved)
+	}
+	m.detectSize()
+	frame := m.frame()
+	if m.beneath != nil {
+		frame = m.overlaidFrame(frame)
 	}
-	var lines []string
-	m.currentMenu.hotKeys = make(map[string]int)
-	lines = append(lines, fmt.Sprintf("Menu: %s", chalk.Bold.TextStyle(m.currentMenu.name)))
-	if m.currentMenu.promptFunction != nil {
-		m.currentMenu.prompt = m.currentMenu.promptFunction()
+	state.lastRenderLines = m.rows(frame)
+	if moved > 0 && strings.HasPrefix(frame, "\n") { // clear what is left of longer lines (e.g. a countdown ending)
+		frame = "\n" + strings.Replace(frame[1:], "\n", "\033[K\n", -1) + "\033[K"
 	}
-	if m.currentMenu.prompt != "" {
-		m.currentMenu.prompt = strings.Replace(m.currentMenu.prompt, "\r\n", "\n", -1)
-		m.currentMenu.prompt = strings.Replace(m.currentMenu.prompt, "\n\r", "\n", -1)
-		promptLines := strings.Split(m.currentMenu.prompt, "\n")
-		for _, l := range promptLines {
-			lines = append(lines, fmt.Sprintf(" %v", l))
+	if state.lastRenderLines < moved {
+		frame += "\033[J" // clear what is left of a taller frame
+	}
+	fmt.Fprint(m.out, frame)
+	m.debug("render", "menu", m.currentMenu.name, "moved_up", moved, "lines", state.lastRenderLines,
+		"width", m.width, "selection", state.selection)
+}
+
+// RenderString will return the current menu frame exactly as it would be drawn, without writing to the terminal
+// (useful for snapshot testing menu layouts, or reusing the layout in another front-end)
+func (m *MenuTree) RenderString() string {
+	return m.frame()
+}
+
+// menuFrame builds the current menu frame (see frame)
+func (m *MenuTree) menuFrame() string {
+	state := m.state(m.currentMenu)
+	lines := make([]string, 0, len(m.currentMenu.optionsOrder)+len(m.subMenuMap[m.currentMenu])+8)
+	state.resetHotKeys()
+	for i, name := range m.currentMenu.optionsOrder {
+		if o := m.currentMenu.options[name]; o.hotKey != "" && m.visible(m.currentMenu, i) {
+			state.hotKeys[strings.ToUpper(o.hotKey)] = i
 		}
 	}
-	for i, o := range m.currentMenu.optionsOrder {
-		if i == 0 {
-			lines = append(lines, fmt.Sprintf("%s", chalk.Bold.TextStyle("Options:")))
+	banner, menuStyle := m.environmentBanner(m.currentMenu.style.merge(m.Theme))
+	if m.flashing {
+		menuStyle.Frame = m.flashStyle()
+	}
+	title := fmt.Sprintf(m.Strings.Menu, apply(menuStyle.Title, m.currentMenu.name))
+	if banner != "" {
+		title += "  " + banner
+	}
+	lines = append(lines, title)
+	lines = append(lines, state.promptLines(m.Prompt())...)
+	cells := make([]string, 0, len(m.currentMenu.optionsOrder))
+	state.cellIndexes = state.cellIndexes[:0]
+	favorite := m.favoriteNames()
+	widths := m.tableWidths()
+	for i, name := range m.currentMenu.optionsOrder {
+		opt := m.currentMenu.options[name]
+		if (opt.hidden && !m.revealed) || !m.visible(m.currentMenu, i) {
+			continue
+		}
+		state.cellIndexes = append(state.cellIndexes, i)
+		if opt.separator {
+			cells = append(cells, " "+apply(menuStyle.Heading, opt.label))
+			continue
 		}
-		if hk := m.currentMenu.assignHotkey(o, i); hk != "" {
-			o = strings.Replace(o, hk, chalk.Underline.TextStyle(hk), 1)
+		st := opt.style.merge(menuStyle)
+		if opt.disabled {
+			st.Label, st.Selected = compose(st.Disabled, st.Label), compose(st.Disabled, st.Selected)
 		}
-		if i == m.currentMenu.selection {
-			lines = append(lines, fmt.Sprintf(">%s", chalk.Italic.TextStyle(o)))
+		var o string
+		if opt.cells != nil {
+			o = alignCells(opt.cells, widths)
 		} else {
-			lines = append(lines, fmt.Sprintf(" %s", o))
+			o = sanitize(evaluate(name, opt.labelFunc))
+		}
+		if opt.hotKey != "" {
+			o = underlineHotKey(o, opt.hotKey, st.HotKey)
+		} else if hk := state.assignHotkey(o, i); hk != "" {
+			o = underlineHotKey(o, hk, st.HotKey)
 		}
+		if opt.subMenu != nil {
+			o += " " + m.SubMenuMarker
+		}
+		if favorite[name] {
+			o += " " + string(favoriteMarker)
+		}
+		o = decorate(opt.glyph, o, evaluate(opt.badge, opt.badgeFunc))
+		o += m.asyncSuffix(opt) + m.cooldownSuffix(opt)
+		if i == state.selection {
+			cells = append(cells, ">"+apply(st.Selected, o))
+		} else {
+			cells = append(cells, " "+apply(st.Label, o))
+		}
+	}
+	if len(m.currentMenu.optionsOrder) > 0 && (len(cells) > 0 || m.visibleFunc == nil) {
+		lines = append(lines, apply(menuStyle.Heading, m.Strings.Options))
+		if widths != nil && len(m.currentMenu.tableHeader) > 0 {
+			lines = append(lines, " "+apply(menuStyle.Heading, strings.TrimRight(alignCells(m.currentMenu.tableHeader, widths), " ")))
+		}
+		lines = append(lines, m.arrangeColumns(cells)...)
 	}
-	if smm, ok := m.subMenuMap[m.currentMenu]; ok {
-		lines = append(lines, fmt.Sprintf("%s", chalk.Bold.TextStyle("SubMenus:")))
+	if smm, ok := m.subMenuMap[m.currentMenu]; ok && m.anyVisibleSubMenu() {
+		lines = append(lines, apply(menuStyle.Heading, m.Strings.SubMenus))
 		for i, sm := range smm {
 			mIdx := i + len(m.currentMenu.optionsOrder)
-			line := sm.name
-			if hk := m.currentMenu.assignHotkey(line, mIdx); hk != "" {
-				line = strings.Replace(line, hk, chalk.Underline.TextStyle(hk), 1)
+			if !m.visible(m.currentMenu, mIdx) {
+				continue
 			}
-			if mIdx == m.currentMenu.selection {
-				lines = append(lines, fmt.Sprintf(">%s", chalk.Italic.TextStyle(line)))
+			line := sanitize(sm.name)
+			if hk := state.assignHotkey(line, mIdx); hk != "" {
+				line = underlineHotKey(line, hk, menuStyle.HotKey)
+			}
+			line = decorate(sm.glyph, line, evaluate(sm.badge, sm.badgeFunc))
+			if mIdx == state.selection {
+				lines = append(lines, ">"+apply(menuStyle.Selected, line))
 			} else {
-				lines = append(lines, fmt.Sprintf(" %s", line))
+				lines = append(lines, " "+apply(menuStyle.Label, line))
 			}
 		}
 	}
+	if rows := m.navigationLines(menuStyle); len(rows) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, rows...)
+	}
+	lines = m.addPreview(lines, menuStyle)
+	if countdown := m.countdownLine(); countdown != "" {
+		lines = append(lines, "", countdown)
+	}
+	if notice := m.noticeLine(); notice != "" {
+		lines = append(lines, "", notice)
+	}
 	lines = append(lines, "")
+	exitLabel := ""
+	if !m.HideExit {
+		exitLabel = underlineHotKey(m.ExitLabel, "x", menuStyle.HotKey)
+	}
+	previous := ""
 	if m.previousMenu != nil {
-		lines = append(lines, fmt.Sprintf(" %c/esc back to %s, E%sit ", leftArrow, m.previousMenu.name, chalk.Underline.TextStyle("x")))
-	} else {
-		lines = append(lines, fmt.Sprintf("E%sit", chalk.Underline.TextStyle("x")))
-	}
-	m.currentMenu.longestLine = 0
-	for _, l := range lines {
-		if len(l) > m.currentMenu.longestLine {
-			m.currentMenu.longestLine = len(l)
-		}
-	}
-	m.currentMenu.longestLine += 2
-	m.currentMenu.lastRenderLines = len(lines) + 1
-	header := "\n"
-	for i := 0; i < m.currentMenu.longestLine+4; i++ {
-		header += "*"
-	}
-	fmt.Println(header)
-	for idx, l := range lines {
-		fillLength := m.currentMenu.longestLine - len(l)
-		if idx < len(lines)-1 {
-			l = "  " + l + "\n"
-			fmt.Print(l)
-		} else {
-			l = "**" + l
-			for i := 0; i < fillLength; i++ {
-				l += "*"
-			}
-			l += "**"
-			fmt.Print(l)
-		}
+		previous = m.previousMenu.name
+	}
+	footer := m.footer(previous, exitLabel)
+	if last := m.lastActionLine(); last != "" {
+		footer = strings.TrimRight(footer, " ") + "  " + last + " "
 	}
+	lines = append(lines, footer)
+	var sb strings.Builder
+	sb.WriteString(m.box(m.currentMenu.name, banner, lines, menuStyle))
+	status := m.statusLine()
+	m.statusShown = status != ""
+	if m.statusShown {
+		sb.WriteString("\n" + status)
+	}
+	return sb.String()
 }
 
 // Display will initiate the menu tree (after initial config) and render the current menu
-func (m *MenuTree) Display() {
+// returning how the session ended (and the terminal error if that was the reason)
+func (m *MenuTree) Display() (reason ExitReason, err error) {
 	m.displaying = true
-	m.currentMenu.selection = 0
-	defer func() {
-		fmt.Printf("\033[?25h")
-	}()
-	redrawPrevious := m.Redraw
-	m.Redraw = false
-	fmt.Println("Welcome to go menu tree.")
-	fmt.Printf("%c to move selection cursor.\n", upDownArrow)
-	fmt.Printf("%c/Enter/H%stkey to choose.\n", rightArrow, chalk.Underline.TextStyle("o"))
-	fmt.Printf("%c/Esc to go back, %s to Exit.\n", leftArrow, chalk.Underline.TextStyle("x"))
-	fmt.Println("` (backtick) to toggle redraw (small terminals may scramble)")
-	fmt.Println("Press any key to start menu...")
-	m.getInput()
-	m.render()
-	m.Redraw = redrawPrevious
-	fmt.Printf("\033[?25l")
-	for m.displaying {
-		input := strings.ToUpper(m.getInput())
-		switch input {
-		case "UP":
-			m.currentMenu.selection -= 1
-			if m.currentMenu.selection < 0 {
-				m.currentMenu.selection = len(m.currentMenu.optionsOrder) - 1
-				if smm, ok := m.subMenuMap[m.currentMenu]; ok {
-					m.currentMenu.selection += len(smm)
-				}
+	defer m.closeTTY()
+	if m.recovery != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				reason, err = ExitPanic, m.crashed(r)
 			}
-			m.render()
-		case "DOWN":
-			m.currentMenu.selection += 1
-			total := len(m.currentMenu.optionsOrder) - 1
-			if smm, ok := m.subMenuMap[m.currentMenu]; ok {
-				total += len(smm)
-			}
-			if m.currentMenu.selection > total {
-				m.currentMenu.selection = 0
-			}
-			m.render()
-		case "ENTER":
-			m.execute(m.currentMenu.selection)
-		case "BACK":
-			if m.previousMenu != nil {
-				m.ChangeMenu(m.previousMenu)
+		}()
+	}
+	m.exitReason, m.inputErr, m.result = ExitUser, nil, nil
+	m.title = ""
+	m.setStopped(false)
+	m.restoreSaved()
+	if m.SerialConsole {
+		defer m.serial()()
+	}
+	if m.Accessible { //nothing is written over, the menu only ever appends lines
+		redrawPrevious := m.Redraw
+		m.Redraw, m.spokenMenu = false, nil
+		defer func() {
+			m.Redraw = redrawPrevious
+		}()
+	}
+	m.initSelection()
+	m.detectLineMode()
+	if m.lineMode || m.region != nil {
+		m.currentMenu = m.enterLoaded(m.currentMenu, m.previousMenu)
+		m.startCountdown()
+		m.render()
+	} else {
+		defer func() {
+			fmt.Fprintf(m.out, "\033[?25h\033[?2004l")
+		}()
+		if m.TerminalTitle {
+			fmt.Fprint(m.out, "\033[22;0t") //save the title, restored on exit
+			defer fmt.Fprint(m.out, "\033[23;0t")
+		}
+		fmt.Fprintf(m.out, "\033[?2004h") //bracketed paste, so pastes are not taken as keys
+		redrawPrevious := m.Redraw
+		m.Redraw = false
+		fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.Welcome, upDownArrow, rightArrow, leftArrow,
+			chalk.Underline.TextStyle("o"), chalk.Underline.TextStyle("x")))
+		switch m.getInput() {
+		case "ERROR":
+			m.displaying = false
+			return ExitError, m.inputErr
+		case "INTERRUPT":
+			m.displaying = false
+			return ExitInterrupt, nil
+		}
+		m.currentMenu = m.enterLoaded(m.currentMenu, m.previousMenu)
+		m.startCountdown()
+		m.render()
+		m.Redraw = redrawPrevious
+		m.hideCursor()
+	}
+	for m.displaying {
+		timeout := m.countdownTimeout() //before going idle, as remote actions may change the menu from then on
+		m.setIdle(true)
+		input := strings.ToUpper(m.getInputWithin(timeout))
+		m.setIdle(false)
+		if m.countingDown() {
+			if input == "IDLE" {
+				m.tickCountdown()
+				continue
 			}
-		case "TOGGLE":
+			m.countdownEnd = time.Time{}
 			if m.Redraw {
-				m.Redraw = false
-				fmt.Println("\nredraw disabled")
 				m.render()
-			} else {
-				fmt.Println("\nredraw enabled")
-				m.render()
-				m.Redraw = true
-			}
-		case "":
-		//do nothing
-		case "EXIT":
-			if i, ok := m.currentMenu.hotKeys[input]; !ok {
-				m.displaying = false
-			} else {
-				m.execute(i)
 			}
-		default:
-			if i, ok := m.currentMenu.hotKeys[input]; ok {
-				m.currentMenu.selection = i
-				m.execute(i)
+		}
+		m.handleInput(input)
+		if m.displaying && m.isStopped() {
+			m.end(ExitStopped)
+		}
+	}
+	fmt.Fprintln(m.out)
+	return m.ExitReason()
+}
+
+// handleKey will act on a single (upper-cased) key event in the current menu
+func (m *MenuTree) handleKey(input string) {
+	if m.hostOut != nil {
+		m.hostOut.Reset()
+	}
+	if m.Accessible && m.typedNumber(input) {
+		return
+	}
+	if input != "IDLE" && m.clearNotice() {
+		m.render()
+	}
+	if m.revealSequenceEntered(input) {
+		m.revealed = !m.revealed
+		m.render()
+		return
+	}
+	if m.favorites != nil && input == m.favorites.key {
+		m.toggleFavorite()
+		m.render()
+		return
+	}
+	if m.searchKey != "" && input == m.searchKey {
+		m.search()
+		return
+	}
+	if function, ok := m.keyBindings[input]; ok {
+		m.releaseTTY()
+		function()
+		m.render()
+		return
+	}
+	switch input {
+	case "ERROR":
+		m.end(ExitError)
+	case "INTERRUPT":
+		m.end(ExitInterrupt)
+	case "SUSPEND":
+		//only a Ctrl+Z typed on the controlling terminal stops the process (not one from SetIO, an input function or a
+		//remote session)
+		if m.tty != nil && m.ownsTerminal() && m.in == nil && m.inputFunc == nil {
+			stopJob()
+		}
+	case "RESUME":
+		m.suspended = false
+		m.retake()
+	case "UP":
+		m.moveSelection(-1)
+		m.render()
+	case "DOWN":
+		m.moveSelection(1)
+		m.render()
+	case "HOME", "END":
+		m.selectEdge(input == "HOME")
+		m.render()
+	case "PGUP":
+		m.movePage(-1)
+		m.render()
+	case "PGDN":
+		m.movePage(1)
+		m.render()
+	case "LEFT":
+		if m.moveColumn(-1) {
+			m.render()
+		} else if m.previousMenu != nil {
+			m.ChangeMenu(m.previousMenu)
+		} else {
+			m.invalidKey(input)
+		}
+	case "RIGHT":
+		if m.moveColumn(1) {
+			m.render()
+		} else if m.state(m.currentMenu).columnRows == 0 {
+			m.execute(m.state(m.currentMenu).selection)
+		}
+	case "ENTER":
+		m.execute(m.state(m.currentMenu).selection)
+	case "BACK":
+		if m.previousMenu != nil {
+			m.ChangeMenu(m.previousMenu)
+		} else {
+			m.invalidKey(input)
+		}
+	case "TOGGLE":
+		m.debug("redraw toggled", "redraw", !m.Redraw)
+		if m.Redraw {
+			m.Redraw = false
+			fmt.Fprintln(m.out, "\nredraw disabled")
+			m.render()
+		} else {
+			fmt.Fprintln(m.out, "\nredraw enabled")
+			m.render()
+			m.Redraw = true
+		}
+	case "IDLE":
+		m.onIdle()
+	case "", "PASTE":
+	//do nothing (text pasted into the menu is ignored rather than taken as hotkeys)
+	case "EXIT":
+		if i, ok := m.state(m.currentMenu).hotKeys[input]; !ok {
+			if m.exitAllowed() {
+				m.end(ExitUser)
 			}
+		} else {
+			m.execute(i)
+		}
+	default:
+		if i, ok := m.state(m.currentMenu).hotKeys[input]; ok {
+			m.state(m.currentMenu).selection = i
+			m.execute(i)
+		} else if m.isUndoKey(input) {
+			m.undoLast()
+		} else {
+			m.invalidKey(input)
+		}
+	}
+}
+
+// moveSelection will move the selection cursor by delta (wrapping around), skipping entries that can not be selected
+func (m *MenuTree) moveSelection(delta int) {
+	state := m.state(m.currentMenu)
+	total := m.entryCount()
+	selection := state.selection
+	for i := 0; i < total; i++ {
+		selection = ((selection+delta)%total + total) % total
+		if m.selectable(selection) {
+			state.selection = selection
+			return
 		}
 	}
-	fmt.Println()
+}
+
+// selectable reports whether the entry at index can be selected
+// (separators can not, nor hidden options until revealed, nor disabled options if skipped, nor entries the
+// visibility function rejects)
+func (m *MenuTree) selectable(index int) bool {
+	if index >= 0 && index < len(m.currentMenu.optionsOrder) {
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && (o.separator || (o.disabled && m.SkipDisabled) || (o.hidden && !m.revealed)) {
+			return false
+		}
+	}
+	return m.visible(m.currentMenu, index)
 }
 
 // execute will act on an option > function selection or go into a submenu, depending on selection
 func (m *MenuTree) execute(index int) {
+	if !m.visible(m.currentMenu, index) {
+		return
+	}
+	m.releaseTTY() //options run with the terminal as they found it (e.g. reading stdin)
+	if row := m.navigationRow(index); row != "" {
+		if row == "BACK" {
+			m.ChangeMenu(m.previousMenu)
+		} else if m.exitAllowed() {
+			m.end(ExitUser)
+		}
+		return
+	}
 	if index >= 0 && index < len(m.currentMenu.optionsOrder) {
-		if m.Redraw {
-			fmt.Printf("\033[%dA", 2)
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.separator {
+			return
+		} else if ok && o.disabled {
+			fmt.Fprintln(m.out, "\n"+fmt.Sprintf(m.Strings.Disabled, m.currentMenu.optionsOrder[index], o.reason))
+			fmt.Fprintln(m.out, m.continuePrompt())
+			m.state(m.currentMenu).lastRenderLines += 2
+			m.getInput()
+			m.render()
+			return
+		}
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.subMenu != nil {
+			m.enterSubMenu(o.subMenu)
+			return
+		}
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.jump != nil {
+			menu := m.currentMenu
+			o.jump()
+			if m.currentMenu == menu { //e.g. a list menu page changed
+				m.render()
+			}
+			return
+		}
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && m.blocked(m.currentMenu.optionsOrder[index], o) {
+			m.render()
+			return
+		}
+		confirm := false
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.confirmPhrase != "" {
+			if !m.confirmed(m.currentMenu.optionsOrder[index], o.confirmPhrase) {
+				return
+			}
+			confirm = true
+		}
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.asyncFunction != nil {
+			m.recordMacroStep(m.currentMenu, m.currentMenu.optionsOrder[index], o)
+			m.trackUsage(m.currentMenu, m.currentMenu.optionsOrder[index])
+			m.startAsync(m.currentMenu, m.currentMenu.optionsOrder[index], o)
+			m.render()
+			return
+		}
+		prompted := confirm
+		if confirm {
+			m.state(m.currentMenu).lastRenderLines = 0
+		}
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.gate != nil {
+			if !m.authorize(m.currentMenu.optionsOrder[index], o.gate) {
+				return
+			}
+			prompted = true
+			m.state(m.currentMenu).lastRenderLines = 0
+		}
+		var argFunction func() error
+		if o, ok := m.currentMenu.options[m.currentMenu.optionsOrder[index]]; ok && o.args != nil {
+			f, accepted := m.promptArgs(o.args)
+			m.state(m.currentMenu).lastRenderLines = 0
+			if !accepted {
+				m.render()
+				return
+			}
+			argFunction = f
+			prompted = true
 		}
-		m.currentMenu.lastRenderLines = 0
 		fName := m.currentMenu.optionsOrder[index]
-		line := fmt.Sprintf("\n*** Executing %s... ***", fName)
-		fill := m.currentMenu.longestLine - len(line)
+		banner, framed := "", m.BeforeExecuteBanner == nil
+		if !framed {
+			banner = m.BeforeExecuteBanner(fName)
+		}
+		if m.Redraw && !m.lineMode && !m.hosted && !prompted && (framed || banner != "") {
+			up := 2 + m.footerRows
+			if m.statusShown {
+				up++
+			}
+			fmt.Fprintf(m.out, "\033[%dA", up)
+		}
+		m.state(m.currentMenu).lastRenderLines = 0
+		line := "\n*** " + fmt.Sprintf(m.Strings.Executing, fName) + " ***"
+		fill := m.state(m.currentMenu).longestLine - displayWidth(line)
 		if fill > 0 {
-			for i := 0; i < fill; i++ {
-				line += "*"
+			line += strings.Repeat("*", fill)
+		}
+		if !framed {
+			line = banner
+			if banner != "" {
+				line = "\n" + banner
 			}
 		}
-		fmt.Println(line)
-		if f, ok := m.currentMenu.options[fName]; ok {
-			line = "------------- Output -------------"
-			fill = m.currentMenu.longestLine - len(line)
-			if fill > 0 {
-				for i := 0; i < fill; i++ {
-					line += "-"
+		if line != "" {
+			fmt.Fprintln(m.out, line)
+		}
+		o, ok := m.currentMenu.options[fName]
+		function := func() {}
+		if ok {
+			m.recordMacroStep(m.currentMenu, fName, o)
+			m.trackUsage(m.currentMenu, fName)
+			menu := m.currentMenu
+			function = func() {
+				m.reportFailure(fName, o, m.audited(menu, fName, func() error {
+					return m.invoke(fName, o, argFunction, os.Stdin, true)
+				}))
+			}
+		}
+		if ok {
+			run := function
+			function = func() {
+				m.inOption = true
+				defer func() {
+					m.inOption = false
+				}()
+				run()
+				m.startCooldown(o)
+			}
+		}
+		if ok && m.hosted {
+			m.runHosted(function)
+		} else if ok && m.region != nil {
+			m.runHosted(function)
+			if !m.displaying {
+				return
+			}
+			m.pauseAfter(o)
+			m.hostOut.Reset()
+			m.render()
+		} else if ok && (m.Pager || o.pager) {
+			lines := m.capture(function)
+			if !m.displaying { //the option ended the session (see ExitWith)
+				return
+			}
+			m.page(lines)
+			fmt.Fprintln(m.out)
+			m.render()
+		} else if ok {
+			if framed {
+				line = rule(m.Strings.Output)
+				fill = m.state(m.currentMenu).longestLine - displayWidth(line)
+				if fill > 0 {
+					line += strings.Repeat("-", fill)
 				}
+				fmt.Fprintln(m.out, line)
 			}
-			fmt.Println(line)
-			f()
-			line = "-------------- End ---------------"
-			fill = m.currentMenu.longestLine - len(line)
+			start := time.Now()
+			m.boundOutput()
+			m.runCopyable(function)
+			m.unboundOutput()
+			if !m.displaying {
+				return
+			}
+			line = rule(m.Strings.End)
+			fill = m.state(m.currentMenu).longestLine - displayWidth(line)
 			if fill > 0 {
-				for i := 0; i < fill; i++ {
-					line += "-"
-				}
+				line += strings.Repeat("-", fill)
 			}
-			fmt.Println(line)
-			fmt.Println("(Press any key to continue)")
-			m.getInput()
-			fmt.Println()
+			if m.AfterExecuteBanner != nil {
+				line = m.AfterExecuteBanner(fName, time.Since(start))
+			}
+			if line != "" {
+				fmt.Fprintln(m.out, line)
+			}
+			m.pauseAfter(o)
+			fmt.Fprintln(m.out)
 			m.render()
 		} else {
-			fmt.Println("\nError, function not found in Options map.")
-			fmt.Println("(Press any key to continue)")
+			fmt.Fprintln(m.out, "\n"+m.Strings.FunctionNotFound)
+			fmt.Fprintln(m.out, m.continuePrompt())
 		}
 	} else {
 		subIndex := index - len(m.currentMenu.optionsOrder)
 		if smm, ok := m.subMenuMap[m.currentMenu]; !ok {
-			fmt.Println("\nError, menu not found in subMenu map.")
-			fmt.Println("(Press any key to continue)")
-			m.currentMenu.lastRenderLines += 2
+			fmt.Fprintln(m.out, "\n"+m.Strings.MenuNotFound)
+			fmt.Fprintln(m.out, m.continuePrompt())
+			m.state(m.currentMenu).lastRenderLines += 2
 			m.getInput()
 			m.render()
 		} else {
 			if subIndex >= 0 && subIndex < len(smm) {
-				m.ChangeMenu(smm[subIndex])
+				m.enterSubMenu(smm[subIndex])
 			} else {
-				fmt.Println("\nError, function not found in Options map.")
-				fmt.Println("(Press any key to continue)")
-				m.currentMenu.lastRenderLines += 2
+				fmt.Fprintln(m.out, "\n"+m.Strings.FunctionNotFound)
+				fmt.Fprintln(m.out, m.continuePrompt())
+				m.state(m.currentMenu).lastRenderLines += 2
 				m.getInput()
 				m.render()
 			}
@@ -386,64 +1001,218 @@ func (m *MenuTree) execute(index int) {
 }
 
 // assignHotKey handles auto-creating hotkeys for named entries, while avoiding duplication
-func (m *Menu) assignHotkey(name string, index int) (hotkey string) {
-	for _, ch := range strings.Split(name, "") {
-		uch := strings.ToUpper(ch)
-		if uch == "X" {
+func (s *menuState) assignHotkey(name string, index int) (hotkey string) {
+	var upper [utf8.UTFMax]byte
+	for i := 0; i < len(name); {
+		if n := escapeLength(name[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(name[i:])
+		ch := name[i : i+size]
+		i += size
+		uch := upper[:utf8.EncodeRune(upper[:], unicode.ToUpper(r))]
+		if r >= utf8.RuneSelf {
+			uch = []byte(strings.ToUpper(ch))
+		}
+		if string(uch) == "X" {
 			continue
 		}
-		if _, ok := m.hotKeys[uch]; !ok {
-			m.hotKeys[uch] = index
+		if _, ok := s.hotKeys[string(uch)]; !ok { //looked up without allocating a key string
+			s.hotKeys[string(uch)] = index
 			return ch
 		}
 	}
 	return ""
 }
 
-// getInput will listen for a single keystroke (for navigating the menu)
+// SetInputFunc will replace keystroke reading from the terminal with the given function (e.g. for scripted tests in CI)
+// the function must return a single key event: "UP", "DOWN", "LEFT", "RIGHT", "HOME", "END", "PGUP", "PGDN",
+// "ENTER", "BACK", "TOGGLE", "EXIT", "INTERRUPT", "SUSPEND" (Ctrl+Z), a key bound with BindKey or a hotkey character
+// every keystroke the menu waits for is requested, including "press any key" pauses; nil restores terminal input
+func (m *MenuTree) SetInputFunc(inputFunc func() string) {
+	m.inputFunc, m.events = inputFunc, nil
+}
+
+// ScriptedInput will return an input function (for SetInputFunc) that feeds the given key events in order,
+// returning "EXIT" once the script is exhausted so the menu can not hang waiting for input
+func ScriptedInput(keys ...string) func() string {
+	var mu sync.Mutex
+	idx := 0
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		if idx >= len(keys) {
+			return "EXIT"
+		}
+		idx++
+		return keys[idx-1]
+	}
+}
+
+// getInput will listen for a single keystroke (for navigating the menu), recording it if a recording is active
+// a terminal error is kept for Display to return, and reported as the "ERROR" key event
 func (m *MenuTree) getInput() string {
-	tty, tErr := term.Open("/dev/tty")
-	if tErr != nil {
-		panic(tErr)
+	return m.getInputWithin(0)
+}
+
+// getInputWithin will listen for a keystroke like getInput, returning the "IDLE" key event if none arrives within the
+// timeout (0 waits for ever)
+func (m *MenuTree) getInputWithin(timeout time.Duration) string {
+	var key string
+	if m.region != nil && m.hostOut.Len() > 0 {
+		m.drawRegion()
 	}
-	defer func() {
-		_ = tty.Restore()
-		_ = tty.Close()
-	}()
-	if e := term.RawMode(tty); e != nil {
-		panic(e)
+	if m.hosted {
+		return ""
 	}
-	bb := make([]byte, 3)
-	if n, e := tty.Read(bb); e != nil {
-		panic(e)
+	for ok := false; !ok; {
+		k, e := m.readInput(timeout)
+		if e == errIdle {
+			return "IDLE"
+		} else if e != nil {
+			m.inputErr = e
+			m.debug("input error", "error", e, "line_mode", m.lineMode)
+			return "ERROR"
+		}
+		key, ok = k, true
+		if m.lineMode || m.inputFunc != nil {
+			m.lastInput = append(m.lastInput[:0], k...)
+		}
+		if m.lineMode {
+			key, ok = m.lineKey(k)
+		}
+	}
+	m.debug("input", "key", key)
+	m.record(key)
+	return key
+}
+
+// ReadLine will print the prompt and read a line of text typed by the user (e.g. for option parameters)
+// when an input function is set (see SetInputFunc) the next event it returns is used as the whole line
+func (m *MenuTree) ReadLine(prompt string) string {
+	fmt.Fprint(m.out, prompt)
+	var line string
+	if m.hosted {
+		fmt.Fprintln(m.out)
+	} else if m.inputFunc != nil {
+		line, _ = m.inputEvents().next(0)
+		fmt.Fprintln(m.out, line)
+	} else if m.in != nil {
+		line, _ = m.readLineFrom(false, 0)
+	} else if m.lineMode {
+		line, _ = m.readLineInput(0)
 	} else {
-		if n == 3 {
-			switch bb[2] {
-			case up:
-				return "UP"
-			case down:
-				return "DOWN"
-			case left:
-				return "BACK"
-			case right:
-				return "ENTER"
-			default:
-				return "DOWN"
+		line = m.readLine()
+	}
+	m.record(line)
+	return line
+}
+
+// readLine will read a line of text from the terminal in cooked mode, with the cursor visible
+func (m *MenuTree) readLine() string {
+	tty := m.tty
+	if tty == nil {
+		var tErr error
+		if tty, tErr = openTTY(); tErr != nil {
+			panic(tErr)
+		}
+		defer func() {
+			_ = tty.close()
+		}()
+	}
+	tty.restore()
+	fmt.Fprintf(m.out, "\033[?25h\033[?2004l")
+	if m.displaying {
+		defer func() {
+			m.hideCursor()
+			fmt.Fprintf(m.out, "\033[?2004h")
+		}()
+	}
+	line, e := bufio.NewReader(tty).ReadString('\n')
+	if e != nil && e != io.EOF {
+		panic(e)
+	}
+	return strings.TrimRight(line, "\r\n")
+}
+
+// readKey will read a single keystroke from the terminal (or the reader set with SetIO)
+func (m *MenuTree) readKey(timeout time.Duration) (string, error) {
+	if key, ok := m.keys.next(false); ok {
+		return m.keyEvent(key), nil
+	}
+	if m.in != nil {
+		for {
+			chunk, e := m.inChunks().next(timeout)
+			if e != nil {
+				return "", e
 			}
-		} else {
-			switch bb[0] {
-			case enter:
-				return "ENTER"
-			case escape:
-				return "BACK"
-			case backtick:
-				return "TOGGLE"
-			case exitX, ctrlC:
-				return "EXIT"
-			default:
-				return string(bb[0])
+			m.keys.feed([]byte(chunk))
+			if !bytes.HasPrefix(m.keys.pending, pasteStart) { //a paste continues over the next reads
+				break
+			}
+			if key, ok := m.keys.next(false); ok {
+				return m.keyEvent(key), nil
 			}
+			timeout = 0
 		}
+		key, _ := m.keys.next(true)
+		return m.keyEvent(key), nil
+	}
+	tty, done, tErr := m.rawTTY()
+	if tErr != nil {
+		m.debug("raw mode failed", "error", tErr)
+		return "", tErr
+	}
+	defer done()
+	if len(m.keys.pending) == 0 && (timeout > 0 || tty.wake != nil) {
+		ready, e := tty.waitKey(timeout)
+		if e == errContinued { //the terminal mode may have been changed while the process was stopped
+			tty.restore()
+			if e = tty.makeRaw(); e != nil {
+				return "", e
+			}
+			return "RESUME", nil
+		} else if e != nil {
+			return "", e
+		}
+		if !ready {
+			return "", errIdle
+		}
+	}
+	key, e := m.nextKey(tty)
+	if e != nil {
+		return "", e
+	}
+	return m.keyEvent(key), nil
+}
+
+// parseKey will translate the bytes of a single keystroke (see keyDecoder) into a key event
+// escape sequences of unknown keys are ignored
+func parseKey(bb []byte) string {
+	if len(bb) == 0 {
+		return ""
+	}
+	if _, ok := pasted(bb); ok {
+		return "PASTE"
+	}
+	if bb[0] == escape && len(bb) > 1 {
+		return escapeKey(bb[1:])
+	}
+	switch bb[0] {
+	case enter:
+		return "ENTER"
+	case escape:
+		return "BACK"
+	case backtick:
+		return "TOGGLE"
+	case exitX:
+		return "EXIT"
+	case ctrlC:
+		return "INTERRUPT"
+	case ctrlZ:
+		return "SUSPEND"
+	default:
+		return string(bb)
 	}
-	return ""
 }
@@ -0,0 +1,71 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetOptionHotKey will give the named option a fixed hotkey (a single character other than "x") instead of
+// one assigned automatically from its label
+func (m *Menu) SetOptionHotKey(name string, hotKey string) {
+	if o, ok := m.options[name]; ok {
+		o.hotKey = hotKey
+	}
+}
+
+// HideOption will hide the named option: it is not drawn and the cursor skips it, but a fixed hotkey set with
+// SetOptionHotKey still executes it, and it is drawn again while hidden options are revealed (see SetRevealSequence)
+func (m *Menu) HideOption(name string) {
+	if o, ok := m.options[name]; ok {
+		o.hidden = true
+	}
+}
+
+// UnhideOption will make a hidden option visible again
+func (m *Menu) UnhideOption(name string) {
+	if o, ok := m.options[name]; ok {
+		o.hidden = false
+	}
+}
+
+// SetRevealSequence will set the sequence of key events (e.g. "UP", "UP", "DOWN", "DOWN" or "D", "B", "G") that
+// toggles revealing hidden options in every menu; the final key of the sequence is consumed, nil disables revealing
+func (m *MenuTree) SetRevealSequence(keys ...string) {
+	m.revealKeys = nil
+	for _, k := range keys {
+		m.revealKeys = append(m.revealKeys, strings.ToUpper(k))
+	}
+	m.keyHistory = nil
+	m.revealed = false
+}
+
+// revealSequenceEntered records the key event and reports whether it completed the reveal sequence
+func (m *MenuTree) revealSequenceEntered(input string) bool {
+	if len(m.revealKeys) == 0 {
+		return false
+	}
+	m.keyHistory = append(m.keyHistory, input)
+	if len(m.keyHistory) > len(m.revealKeys) {
+		m.keyHistory = m.keyHistory[len(m.keyHistory)-len(m.revealKeys):]
+	}
+	if len(m.keyHistory) < len(m.revealKeys) {
+		return false
+	}
+	for i, k := range m.revealKeys {
+		if m.keyHistory[i] != k {
+			return false
+		}
+	}
+	m.keyHistory = nil
+	return true
+}
+
+// underlineHotKey styles the first occurrence (case insensitive) of the fixed hotkey within the label
+// (appending it in parentheses if the label does not contain it)
+func underlineHotKey(label string, hotKey string, styleFunction func(string) string) string {
+	idx := visibleIndex(label, hotKey)
+	if idx < 0 {
+		return fmt.Sprintf("%s (%s)", label, apply(styleFunction, hotKey))
+	}
+	return label[:idx] + apply(styleFunction, label[idx:idx+len(hotKey)]) + label[idx+len(hotKey):]
+}
@@ -0,0 +1,67 @@
+package gomenutree
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Host will prepare the menu tree to be driven by another program's event loop (e.g. a Bubble Tea or tcell
+// application, see the teamenu package) through HandleKey and View instead of Display; nothing is drawn to the
+// terminal, messages and option output are shown below the menu until the next key, and anything that would wait
+// for input (ReadLine, "press any key") gets an empty answer straight away (ConfirmExit is skipped, confirm in the
+// host instead)
+func (m *MenuTree) Host() {
+	m.hosted = true
+	m.displaying = true
+	m.exitReason, m.inputErr = ExitUser, nil
+	m.setStopped(false)
+	m.restoreSaved()
+	m.currentMenu = m.enterLoaded(m.currentMenu, m.previousMenu)
+	m.initSelection()
+	m.hostOut = new(bytes.Buffer)
+	m.out = m.hostOut
+}
+
+// HandleKey will act on a single key event ("UP", "DOWN", "LEFT", "RIGHT", "ENTER", "BACK", "EXIT", "INTERRUPT" or
+// a hotkey) when hosted, reporting whether the menu is still running (see ExitReason once it is not)
+func (m *MenuTree) HandleKey(key string) bool {
+	if !m.hosted {
+		m.Host()
+	}
+	if !m.displaying {
+		return false
+	}
+	m.record(key)
+	m.handleKey(strings.ToUpper(key))
+	if m.displaying && m.isStopped() {
+		m.end(ExitStopped)
+	}
+	return m.displaying
+}
+
+// View will return the current menu frame followed by any message or option output from the last key when hosted
+func (m *MenuTree) View() string {
+	view := strings.TrimPrefix(m.frame(), "\n")
+	if m.hostOut != nil {
+		if out := strings.TrimSpace(m.hostOut.String()); out != "" {
+			view += "\n\n" + out
+		}
+	}
+	return view
+}
+
+// ExitReason will return how the last session ended (and the terminal error if that was the reason)
+func (m *MenuTree) ExitReason() (ExitReason, error) {
+	if m.exitReason == ExitError {
+		return m.exitReason, m.inputErr
+	}
+	return m.exitReason, nil
+}
+
+// runHosted runs an option function with its output captured into the message area
+func (m *MenuTree) runHosted(function func()) {
+	for _, l := range m.capture(function) {
+		fmt.Fprintln(m.out, l)
+	}
+}
@@ -0,0 +1,172 @@
+package gomenutree
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type (
+	// IdleAction selects what happens when the menu has waited too long for input (see SetIdleTimeout)
+	IdleAction int
+
+	// keyResult is a key event (or error) read in the background
+	keyResult struct {
+		key string
+		err error
+	}
+
+	// queuedReads reads a source other than the terminal (the input function, the SetIO reader or stdin in line mode)
+	// one read at a time: a read that gives up after a timeout is left running and its result is taken by the next
+	// read of the source, so the source is never read by two goroutines at once and nothing read is lost
+	queuedReads struct {
+		read    func() (string, error)
+		pending chan keyResult
+	}
+)
+
+const (
+	// IdleExit ends Display with ExitIdle
+	IdleExit IdleAction = iota
+	// IdleHome returns to the home menu
+	IdleHome
+	// IdleLock hides the menu until Enter is pressed
+	IdleLock
+)
+
+// errIdle reports that no key arrived within the idle timeout
+var errIdle = errors.New("gomenutree: idle timeout")
+
+// SetIdleTimeout will take the action after the menu has waited the given time for a key, e.g. to exit or lock a
+// shared operator console (0 disables the timeout)
+func (m *MenuTree) SetIdleTimeout(timeout time.Duration, action IdleAction) {
+	m.idleTimeout = timeout
+	m.idleAction = action
+}
+
+// readInput reads the next key event (or line in line mode) from the input function, the SetIO reader or the terminal,
+// giving up with errIdle after the timeout (0 waits for ever)
+func (m *MenuTree) readInput(timeout time.Duration) (string, error) {
+	switch {
+	case m.lineMode:
+		return m.readLineInput(timeout)
+	case m.inputFunc != nil:
+		return m.inputEvents().next(timeout)
+	}
+	return m.readKey(timeout)
+}
+
+// next returns the result of the source's next read, or errIdle if it does not arrive within the timeout (0 waits
+// for ever)
+func (q *queuedReads) next(timeout time.Duration) (string, error) {
+	if q.pending == nil {
+		if timeout <= 0 {
+			return q.read()
+		}
+		pending := make(chan keyResult, 1)
+		go func() {
+			k, e := q.read()
+			pending <- keyResult{key: k, err: e}
+		}()
+		q.pending = pending
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case r := <-q.pending:
+		q.pending = nil
+		return r.key, r.err
+	case <-expired:
+		return "", errIdle
+	}
+}
+
+// inputEvents returns the queued reads of the input function, one key event (or line) each
+func (m *MenuTree) inputEvents() *queuedReads {
+	if m.events == nil {
+		inputFunc := m.inputFunc
+		m.events = &queuedReads{read: func() (string, error) {
+			return inputFunc(), nil
+		}}
+	}
+	return m.events
+}
+
+// inChunks returns the queued reads of the SetIO reader, the bytes of one read each
+func (m *MenuTree) inChunks() *queuedReads {
+	if m.chunks == nil {
+		in := m.in
+		m.chunks = &queuedReads{read: func() (string, error) {
+			bb := make([]byte, 64)
+			n, e := in.Read(bb)
+			if n > 0 {
+				return string(bb[:n]), nil
+			}
+			return "", e
+		}}
+	}
+	return m.chunks
+}
+
+// stdinLines returns the queued reads of stdin in line mode, one line each
+func (m *MenuTree) stdinLines() *queuedReads {
+	if m.lines == nil {
+		stdin := bufio.NewReader(os.Stdin)
+		m.lines = &queuedReads{read: func() (string, error) {
+			line, e := stdin.ReadString('\n')
+			if e == io.EOF && line != "" {
+				e = nil
+			}
+			return strings.TrimRight(line, "\r\n"), e
+		}}
+	}
+	return m.lines
+}
+
+// onIdle takes the configured idle action
+func (m *MenuTree) onIdle() {
+	m.debug("idle timeout", "timeout", m.idleTimeout.String())
+	switch m.idleAction {
+	case IdleExit:
+		m.end(ExitIdle)
+	case IdleHome:
+		if m.currentMenu != m.homeMenu {
+			m.changeMenu(m.homeMenu, nil)
+		}
+	case IdleLock:
+		m.lock()
+	}
+}
+
+// lock hides the menu behind a message until Enter is pressed
+func (m *MenuTree) lock() {
+	if m.Redraw && !m.lineMode && m.state(m.currentMenu).lastRenderLines > 0 {
+		fmt.Fprintf(m.out, "\033[%dA\r\033[J", m.state(m.currentMenu).lastRenderLines)
+	}
+	fmt.Fprintln(m.out, "\n"+fmt.Sprintf(m.Strings.Locked, m.idleTimeout))
+	for {
+		switch m.getInput() {
+		case "ENTER":
+			if m.Redraw && !m.lineMode {
+				fmt.Fprint(m.out, "\033[2A\r\033[J")
+			}
+			m.state(m.currentMenu).lastRenderLines = 0
+			m.render()
+			return
+		case "ERROR":
+			m.end(ExitError)
+			return
+		case "INTERRUPT":
+			m.end(ExitInterrupt)
+			return
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package gomenutree
+
+// AddSubMenuOption will add the child menu as an entry among the parent menu's options (keyed by the child's name,
+// marked with SubMenuMarker), so submenus can be interleaved with actions in a single ordering (InsertOptionAt,
+// MoveOption and SortOptions place it like any other option); choosing it enters the child menu
+func (m *MenuTree) AddSubMenuOption(parentMenu *Menu, childMenu *Menu) {
+	parentMenu.addOption(childMenu.name, &option{function: func() {}, subMenu: childMenu})
+}
+
+// children returns the submenus reachable from the menu: those listed in its submenu section, then those among its
+// options
+func (m *MenuTree) children(menu *Menu) []*Menu {
+	smm := m.subMenuMap[menu]
+	for _, name := range menu.optionsOrder {
+		if o, ok := menu.options[name]; ok && o.subMenu != nil {
+			smm = append(smm[:len(smm):len(smm)], o.subMenu)
+		}
+	}
+	return smm
+}
+
+// enterSubMenu goes into the submenu once its gate (if any) is passed
+func (m *MenuTree) enterSubMenu(menu *Menu) {
+	if menu.gate != nil && !m.authorize(menu.name, menu.gate) {
+		return
+	}
+	m.ChangeMenu(menu)
+}
@@ -0,0 +1,58 @@
+//go:build !windows
+// +build !windows
+
+package gomenutree
+
+import (
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// watchJobControl hands the terminal back in its original mode (cursor shown, bracketed paste off) before the process
+// stops on SIGTSTP, and makes the terminal's wake file readable when it continues, so the key reader can take raw
+// mode again and have the menu redrawn
+func watchJobControl(tty *ttyFile) {
+	original, e := term.GetState(int(tty.Fd()))
+	if e != nil {
+		return
+	}
+	r, w, e := os.Pipe()
+	if e != nil {
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, unix.SIGTSTP, unix.SIGCONT)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case s := <-signals:
+				if s == unix.SIGTSTP {
+					_ = term.Restore(int(tty.Fd()), original)
+					_, _ = tty.WriteString("\033[?25h\033[?2004l")
+					_ = unix.Kill(unix.Getpid(), unix.SIGSTOP)
+					continue
+				}
+				_, _ = w.Write([]byte{0})
+			}
+		}
+	}()
+	tty.wake = r
+	tty.unwatch = func() {
+		signal.Stop(signals)
+		close(done)
+		_ = w.Close()
+		_ = r.Close()
+		tty.wake, tty.unwatch = nil, nil
+	}
+}
+
+// stopJob stops the process group, as Ctrl+Z does in a shell when the terminal is not in raw mode
+func stopJob() {
+	_ = unix.Kill(0, unix.SIGTSTP)
+}
@@ -0,0 +1,10 @@
+//go:build windows
+// +build windows
+
+package gomenutree
+
+// watchJobControl does nothing: there is no job control on Windows
+func watchJobControl(*ttyFile) {}
+
+// stopJob does nothing: there is no job control on Windows
+func stopJob() {}
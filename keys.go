@@ -0,0 +1,202 @@
+package gomenutree
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// escapeDelay is how long the rest of an escape sequence may take to arrive, after which the escape was the Esc key
+const escapeDelay = 50 * time.Millisecond
+
+var (
+	pasteStart = []byte("\x1b[200~") // bracketed paste mode wraps pasted text in these sequences
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// keyDecoder splits the bytes read from the terminal into keystrokes: escape sequences of any length (even when they
+// arrive over several reads) and UTF-8 characters, keeping what is left of a read for the next key
+type keyDecoder struct {
+	pending []byte
+}
+
+// feed adds bytes read from the terminal
+func (d *keyDecoder) feed(bb []byte) {
+	d.pending = append(d.pending, bb...)
+}
+
+// pasted returns the text of a paste keystroke, false if the keystroke is not one
+func pasted(key []byte) (string, bool) {
+	if !bytes.HasPrefix(key, pasteStart) {
+		return "", false
+	}
+	return string(bytes.TrimSuffix(key[len(pasteStart):], pasteEnd)), true
+}
+
+// next takes the next complete keystroke, or reports false while it is incomplete; with flush, an incomplete one is
+// taken as it is (a lone escape being the Esc key rather than the start of a sequence)
+func (d *keyDecoder) next(flush bool) ([]byte, bool) {
+	if len(d.pending) == 0 {
+		return nil, false
+	}
+	n := keyLength(d.pending)
+	if n == 0 {
+		if !flush {
+			return nil, false
+		}
+		n = len(d.pending)
+	}
+	key := append([]byte(nil), d.pending[:n]...)
+	d.pending = d.pending[n:]
+	return key, true
+}
+
+// keyLength returns the length of the keystroke starting the bytes, 0 if it is incomplete
+func keyLength(bb []byte) int {
+	if bb[0] != escape {
+		if !utf8.FullRune(bb) {
+			return 0
+		}
+		_, size := utf8.DecodeRune(bb)
+		return size
+	}
+	if len(bb) == 1 {
+		return 0
+	}
+	switch bb[1] {
+	case '[': // CSI: parameter and intermediate bytes, then a final byte (a paste runs to its end sequence)
+		if bytes.HasPrefix(bb, pasteStart) {
+			if i := bytes.Index(bb, pasteEnd); i >= 0 {
+				return i + len(pasteEnd)
+			}
+			return 0
+		}
+		for i := 2; i < len(bb); i++ {
+			switch {
+			case bb[i] >= 0x20 && bb[i] <= 0x3f:
+			case bb[i] >= 0x40 && bb[i] <= 0x7e:
+				return i + 1
+			default: // malformed, the rest starts a new key
+				return i
+			}
+		}
+		return 0
+	case 'O': // SS3: a single final byte
+		if len(bb) < 3 {
+			return 0
+		}
+		return 3
+	case escape:
+		return 1
+	}
+	if !utf8.FullRune(bb[1:]) {
+		return 0
+	}
+	_, size := utf8.DecodeRune(bb[1:])
+	return 1 + size // Alt with a character
+}
+
+// BindKey will run the function when the key is pressed in any menu, before hotkeys and the built-in keys, redrawing
+// the menu afterwards; key is a character or a key event such as "F1", "INSERT" or "DELETE" (see SetInputFunc), nil
+// removes the binding
+func (m *MenuTree) BindKey(key string, function func()) {
+	key = strings.ToUpper(key)
+	if function == nil {
+		delete(m.keyBindings, key)
+		return
+	}
+	if m.keyBindings == nil {
+		m.keyBindings = make(map[string]func())
+	}
+	m.keyBindings[key] = function
+}
+
+// nextKey returns the next keystroke from the terminal, reading more when none is pending
+func (m *MenuTree) nextKey(tty *ttyFile) ([]byte, error) {
+	bb := make([]byte, 64)
+	for {
+		if key, ok := m.keys.next(false); ok {
+			return key, nil
+		}
+		if len(m.keys.pending) > 0 {
+			if ready, e := tty.waitInput(escapeDelay); e != nil || !ready {
+				key, _ := m.keys.next(true)
+				return key, nil
+			}
+		}
+		n, e := tty.Read(bb)
+		if e != nil {
+			return nil, e
+		}
+		m.keys.feed(bb[:n])
+	}
+}
+
+// drainInput discards the keys typed ahead (e.g. while an option ran), so they do not dismiss what comes next
+func (m *MenuTree) drainInput() {
+	m.keys.pending = nil
+	if m.in != nil || m.inputFunc != nil || m.lineMode || m.hosted {
+		return
+	}
+	tty, done, e := m.rawTTY()
+	if e != nil {
+		return
+	}
+	defer done()
+	bb := make([]byte, 64)
+	for {
+		if ready, e := tty.waitInput(0); e != nil || !ready {
+			return
+		}
+		if _, e := tty.Read(bb); e != nil {
+			return
+		}
+	}
+}
+
+// escapeKey names the key sending the escape sequence (without its leading escape): arrows, "HOME", "END",
+// "INSERT", "DELETE", "PGUP", "PGDN" or "F1" to "F12", ignoring modifiers (e.g. Ctrl+Up is "UP"); Alt combinations
+// and unknown keys are ""
+func escapeKey(seq []byte) string {
+	if len(seq) < 2 || (seq[0] != '[' && seq[0] != 'O') {
+		return ""
+	}
+	final := seq[len(seq)-1]
+	params := string(seq[1 : len(seq)-1])
+	if i := strings.IndexByte(params, ';'); i >= 0 {
+		params = params[:i]
+	}
+	if final == '~' {
+		return tildeKeys[params]
+	}
+	if params != "" && params != "1" {
+		return ""
+	}
+	switch final {
+	case up:
+		return "UP"
+	case down:
+		return "DOWN"
+	case right:
+		return "RIGHT"
+	case left:
+		return "LEFT"
+	case 'H':
+		return "HOME"
+	case 'F':
+		return "END"
+	case 'P', 'Q', 'R', 'S':
+		return fmt.Sprintf("F%d", final-'P'+1)
+	}
+	return ""
+}
+
+// tildeKeys names the keys sending "ESC [ <number> ~" sequences
+var tildeKeys = map[string]string{
+	"1": "HOME", "7": "HOME", "4": "END", "8": "END",
+	"2": "INSERT", "3": "DELETE", "5": "PGUP", "6": "PGDN",
+	"11": "F1", "12": "F2", "13": "F3", "14": "F4", "15": "F5", "17": "F6",
+	"18": "F7", "19": "F8", "20": "F9", "21": "F10", "23": "F11", "24": "F12",
+}
@@ -0,0 +1,155 @@
+package gomenutree
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// interactive reports whether a raw-mode terminal is available (stdin is a terminal and the controlling terminal, the
+// console on Windows, can be opened)
+func interactive() bool {
+	if fi, e := os.Stdin.Stat(); e != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	tty, e := os.OpenFile(ttyPath, os.O_RDWR, 0)
+	if e != nil {
+		return false
+	}
+	_ = tty.Close()
+	return true
+}
+
+// detectLineMode will switch to the numbered line-mode menu if forced, or if reading the local terminal and
+// there is no raw-mode terminal to read
+func (m *MenuTree) detectLineMode() {
+	m.lineMode = m.LineMode || (m.inputFunc == nil && m.in == nil && !interactive())
+	m.debug("terminal detected", "line_mode", m.lineMode, "forced", m.LineMode)
+}
+
+// lineFrame builds the current menu as a plain numbered list followed by the choice prompt
+func (m *MenuTree) lineFrame() string {
+	state := m.state(m.currentMenu)
+	var sb strings.Builder
+	state.resetHotKeys()
+	state.lineEntries = state.lineEntries[:0]
+	widths := m.tableWidths()
+	sb.WriteString("\n" + fmt.Sprintf(m.Strings.Menu, m.currentMenu.name))
+	if env := m.Environment(); env != "" {
+		sb.WriteString("  [" + strings.TrimSpace(fmt.Sprintf(m.Strings.Environment, strings.ToUpper(sanitize(env)))) + "]")
+	}
+	sb.WriteString("\n")
+	if prompt := m.Prompt(); prompt != "" {
+		for _, l := range strings.Split(strings.Replace(prompt, "\r", "", -1), "\n") {
+			sb.WriteString(" " + sanitize(l) + "\n")
+		}
+	}
+	entry := func(index int, label string) {
+		state.lineEntries = append(state.lineEntries, index)
+		marker := " "
+		if index == state.selection {
+			marker = ">"
+		}
+		sb.WriteString(fmt.Sprintf("%s%d) %s\n", marker, len(state.lineEntries), label))
+	}
+	if len(m.currentMenu.optionsOrder) > 0 {
+		sb.WriteString(m.Strings.Options + "\n")
+	}
+	for i, name := range m.currentMenu.optionsOrder {
+		opt := m.currentMenu.options[name]
+		if opt.hotKey != "" && m.visible(m.currentMenu, i) {
+			state.hotKeys[strings.ToUpper(opt.hotKey)] = i
+		}
+		switch {
+		case (opt.hidden && !m.revealed) || !m.visible(m.currentMenu, i):
+		case opt.separator:
+			sb.WriteString(" " + opt.label + "\n")
+		default:
+			label := sanitize(evaluate(name, opt.labelFunc))
+			if opt.cells != nil {
+				label = strings.TrimRight(alignCells(opt.cells, widths), " ")
+			}
+			if opt.subMenu != nil {
+				label += " " + m.SubMenuMarker
+			}
+			entry(i, label+m.asyncSuffix(opt)+m.cooldownSuffix(opt))
+		}
+	}
+	if smm, ok := m.subMenuMap[m.currentMenu]; ok && m.anyVisibleSubMenu() {
+		sb.WriteString(m.Strings.SubMenus + "\n")
+		for i, sm := range smm {
+			if m.visible(m.currentMenu, i+len(m.currentMenu.optionsOrder)) {
+				entry(i+len(m.currentMenu.optionsOrder), sanitize(sm.name))
+			}
+		}
+	}
+	if m.previousMenu != nil {
+		sb.WriteString(" 0) " + fmt.Sprintf(m.Strings.BackTo, m.previousMenu.name) + "\n")
+	}
+	if !m.HideExit {
+		sb.WriteString(" x) " + m.ExitLabel + "\n")
+	}
+	if countdown := m.countdownLine(); countdown != "" {
+		sb.WriteString(countdown + "\n")
+	}
+	if notice := m.noticeLine(); notice != "" {
+		sb.WriteString(notice + "\n")
+	}
+	if status := m.statusLine(); status != "" {
+		sb.WriteString(status + "\n")
+	}
+	sb.WriteString(m.Strings.Choice)
+	return sb.String()
+}
+
+// readLineInput will read the next line in line mode from the input function, the SetIO reader or stdin, giving up
+// with errIdle if none arrives within the timeout (0 waits for ever)
+func (m *MenuTree) readLineInput(timeout time.Duration) (string, error) {
+	switch {
+	case m.inputFunc != nil:
+		line, e := m.inputEvents().next(timeout)
+		if e == nil {
+			fmt.Fprintln(m.out, line)
+		}
+		return line, e
+	case m.in != nil:
+		return m.readLineFrom(false, timeout)
+	}
+	return m.stdinLines().next(timeout)
+}
+
+// lineKey will translate a line typed in line mode into a key event: an entry number chooses that entry, 0 goes
+// back, x exits, an empty line chooses the current selection and anything else is used as typed (e.g. a hotkey)
+func (m *MenuTree) lineKey(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	n, e := strconv.Atoi(line)
+	switch {
+	case line == "":
+		return "ENTER", true
+	case strings.EqualFold(line, "x"):
+		return "EXIT", true
+	case e != nil:
+		return line, true
+	case n == 0:
+		return "BACK", true
+	case n > 0 && n <= len(m.state(m.currentMenu).lineEntries):
+		m.state(m.currentMenu).selection = m.state(m.currentMenu).lineEntries[n-1]
+		return "ENTER", true
+	}
+	fmt.Fprint(m.out, fmt.Sprintf(m.Strings.InvalidChoice, line)+"\n"+m.Strings.Choice)
+	return "", false
+}
+
+// continuePrompt returns the message shown while waiting for a key (or a line in line mode) to continue, nothing
+// when hosted as there is no wait
+func (m *MenuTree) continuePrompt() string {
+	if m.hosted {
+		return ""
+	}
+	if m.lineMode {
+		return m.Strings.PressEnter
+	}
+	return m.Strings.PressAnyKey
+}
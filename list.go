@@ -0,0 +1,137 @@
+package gomenutree
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ListMenu is a menu listing the items of a slice, one option per item, rebuilt whenever the items change (see
+// SetItems); pass its Menu to AddSubMenu or NewMenuTree like any other menu
+type ListMenu[T any] struct {
+	*Menu
+	label    func(item T) string
+	onSelect func(item T)
+	less     func(a, b T) bool
+	items    []T
+	pageSize int
+	page     int
+
+	// PreviousPage and NextPage label the options changing pages (from the next rebuild), %d is the page then %d
+	// the page count
+	PreviousPage string
+	NextPage     string
+}
+
+// NewListMenu will create a menu listing the items, labelled by label (items with the same label are numbered) and
+// running onSelect with the item chosen
+func NewListMenu[T any](name string, items []T, label func(item T) string, onSelect func(item T)) *ListMenu[T] {
+	l := &ListMenu[T]{
+		Menu:         NewMenu(name, "", nil),
+		label:        label,
+		onSelect:     onSelect,
+		PreviousPage: "Previous page (%d/%d)",
+		NextPage:     "Next page (%d/%d)",
+	}
+	l.SetItems(items)
+	l.Menu.sessionCopy = l.sessionCopy
+	return l
+}
+
+// sessionCopy returns a session's copy of the list (see NewSession), with its own page, listing the items again after
+// the list's loader runs
+func (l *ListMenu[T]) sessionCopy() *Menu {
+	c := *l
+	c.Menu = l.Menu.Clone(false)
+	c.Menu.sessionCopy = nil
+	c.items = l.Items()
+	c.rebuild()
+	if loader := l.onEnter; loader != nil {
+		c.Menu.onEnter = func(ctx context.Context) error {
+			if e := loader(ctx); e != nil {
+				return e
+			}
+			c.items = l.Items()
+			c.rebuild()
+			return nil
+		}
+	}
+	return c.Menu
+}
+
+// SetItems will replace the items listed and rebuild the menu (keeping the page, if it still exists)
+func (l *ListMenu[T]) SetItems(items []T) {
+	l.items = append([]T(nil), items...)
+	if l.less != nil {
+		sort.SliceStable(l.items, func(i, j int) bool { return l.less(l.items[i], l.items[j]) })
+	}
+	l.rebuild()
+}
+
+// Items will return the items listed, in the order shown
+func (l *ListMenu[T]) Items() []T {
+	return append([]T(nil), l.items...)
+}
+
+// SetSort will list the items ordered by less (nil keeps the order they were given in from the next SetItems)
+func (l *ListMenu[T]) SetSort(less func(a, b T) bool) {
+	l.less = less
+	l.SetItems(l.items)
+}
+
+// SetPageSize will list at most size items at a time, with options going to the previous and next pages listed
+// first (0 lists every item)
+func (l *ListMenu[T]) SetPageSize(size int) {
+	l.pageSize = size
+	l.rebuild()
+}
+
+// pages returns the number of pages the items take
+func (l *ListMenu[T]) pages() int {
+	if l.pageSize <= 0 || len(l.items) <= l.pageSize {
+		return 1
+	}
+	return (len(l.items) + l.pageSize - 1) / l.pageSize
+}
+
+// rebuild replaces the menu's options with the page controls and the items of the current page
+func (l *ListMenu[T]) rebuild() {
+	for _, name := range append([]string(nil), l.optionsOrder...) {
+		l.DeleteOption(name)
+	}
+	pages := l.pages()
+	if l.page >= pages {
+		l.page = pages - 1
+	}
+	first, last := 0, len(l.items)
+	if pages > 1 {
+		first = l.page * l.pageSize
+		if last > first+l.pageSize {
+			last = first + l.pageSize
+		}
+		previous := fmt.Sprintf(l.PreviousPage, l.page+1, pages)
+		l.addOption(previous, &option{function: func() {}, jump: func() {
+			l.page = (l.page + pages - 1) % pages
+			l.rebuild()
+		}})
+		next := fmt.Sprintf(l.NextPage, l.page+1, pages)
+		l.addOption(next, &option{function: func() {}, jump: func() {
+			l.page = (l.page + 1) % pages
+			l.rebuild()
+		}})
+	}
+	seen := make(map[string]int)
+	for i, item := range l.items[:last] {
+		item := item
+		name := l.label(item)
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s (%d)", name, seen[name])
+		}
+		if i < first {
+			continue
+		}
+		l.AddOption(name, func() {
+			l.onSelect(item)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package gomenutree
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OnEnter will run the loader each time the menu is entered, before it is drawn (e.g. to fetch the data its options
+// are built from); a spinner is shown while it runs, and if it fails the error is shown in place of the menu along
+// with an option to retry. The context is cancelled if the menu tree is stopped (see Stop)
+func (m *Menu) OnEnter(loader func(ctx context.Context) error) {
+	m.onEnter = loader
+}
+
+// enterLoaded runs the menu's loader if it has one, returning the menu to show: the menu itself, or a menu with the
+// error and a retry option if loading failed
+func (m *MenuTree) enterLoaded(menu *Menu, previous *Menu) *Menu {
+	if menu.onEnter == nil || !m.displaying {
+		return menu
+	}
+	e := m.load(menu.onEnter)
+	if e == nil {
+		m.refreshCopy(menu)
+		return menu
+	}
+	m.debug("menu not loaded", "menu", menu.name, "error", e)
+	if m.loadMenu != nil {
+		delete(m.states, m.loadMenu)
+	}
+	failed := NewMenu(menu.name, fmt.Sprintf(m.Strings.LoadFailed, e), nil)
+	failed.addOption(m.Strings.Retry, &option{function: func() {}, jump: func() {
+		m.changeMenu(menu, previous)
+	}})
+	m.loadMenu = failed
+	return failed
+}
+
+// load runs the loader, animating a spinner on the line below until it returns (when drawing on the terminal)
+func (m *MenuTree) load(loader func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	spin := m.ownsTerminal()
+	if spin {
+		fmt.Fprintln(m.out)
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			if m.isStopped() {
+				cancel()
+			}
+			if spin {
+				fmt.Fprintf(m.out, "\r\033[2K%c %s", spinnerFrames[frame%len(spinnerFrames)], m.Strings.Loading)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	e := loader(ctx)
+	close(stop)
+	<-stopped
+	if spin {
+		fmt.Fprint(m.out, "\r\033[2K\033[A") //the frame starts on a new line
+	}
+	return e
+}
@@ -0,0 +1,192 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Strings holds every built-in message, so applications can translate the menu chrome; format verbs must be kept
+type Strings struct {
+	Menu     string //menu title line, %s is the menu name
+	Options  string //heading above the options
+	SubMenus string //heading above the submenus
+	// Welcome is shown before the first menu: %[1]c is the up/down arrow, %[2]c the right arrow, %[3]c the left
+	// arrow, %[4]s an underlined "o" and %[5]s an underlined "x"
+	Welcome string
+
+	Executing        string //banner above an option's output, %s is the option name
+	Output           string //rule above an option's output
+	End              string //rule below an option's output
+	PressAnyKey      string //shown whenever the menu waits for a key before redrawing
+	PressEnter       string //shown instead of PressAnyKey in line mode
+	PressAnyKeyCopy  string //shown after option output when CopyKey is set, %s is the key
+	AutoReturn       string //shown after option output with PauseTimed, %s is the delay
+	Copied           string //%d is the number of lines copied
+	CopyFailed       string //%v is the error
+	Disabled         string //shown when a disabled option is chosen, %s is the option name then the reason
+	FunctionNotFound string
+	MenuNotFound     string
+
+	ConfirmExit   string //exit confirmation question (y or x confirms)
+	ExitCancelled string //shown when an exit hook cancels the exit, %v is its error
+
+	Running string //async option suffix, %s is the elapsed time
+	Failed  string //async option suffix, %s is the elapsed time then %v the error
+	Done    string //async option suffix, %s is the elapsed time
+
+	PagerStatus string //pager footer, %d first line, %d last line, %d line count then %c the up/down arrow
+	PagerEmpty  string //pager footer when the option printed nothing
+
+	WizardValue  string //text step entry, %s is the current value
+	InvalidValue string //shown when a wizard value or option argument fails validation, %v is the error
+	OptionFailed string //shown when an option handler returns an error, %v is the error
+	ExitStatus   string //shown when a command option's command ends, %d is its exit status
+	MacroStep    string //shown before each step of a macro, %d is the step, %d the step count then %s the option
+	Favorites    string //name of the favorites menu
+	Recent       string //name of the recently used options menu
+
+	Search        string //prompt for the text searched across the tree
+	SearchResults string //name of the search results menu, %s is the text searched
+	NoMatches     string //shown when a search finds nothing, %s is the text searched
+
+	Loading    string //shown with a spinner while a menu's OnEnter loader runs
+	LoadFailed string //prompt shown instead of a menu whose loader failed, %v is the error
+	Retry      string //option running a failed loader again
+
+	Environment string //environment banner in the menu header, %s is the environment name in upper case
+
+	ConfirmPhrase string //inline prompt of a destructive option, %s is the phrase to type
+	NotConfirmed  string //shown when the phrase typed does not match, %s is the option name
+
+	AlreadyRunning string //shown when an async option still running is chosen, %s is its name
+	Busy           string //shown when an option is chosen while another runs and Serialize is set, %s is its name
+	CoolingDown    string //shown when a cooling down option is chosen, %s is its name then the time left
+	Cooldown       string //suffix of a cooling down option, %s is the time left
+
+	RemoteNavigated string //notification of a menu change through the remote API, %s is the menu name
+	RemoteExecuted  string //notification of an option run through the remote API, %s is its name
+
+	TitleSeparator string //between the menu names in the terminal title (see TerminalTitle)
+
+	LastAction    string //footer text of the option that last ran (see ShowLastAction), %s is its name then %s the time since
+	ConfirmUndo   string //undo confirmation question, %s is the option undone (y confirms)
+	UndoOption    string //what an undo is recorded as in the audit log, %s is the option undone
+	Undone        string //shown once an undo has run, %s is the option undone
+	UndoFailed    string //shown when an undo fails, %s is the option then %v the error
+	NothingToUndo string //shown when the undo key is pressed and the last action can not be undone
+
+	TimedOut string //shown in the output of an option that ran longer than its timeout, %s is its name then the timeout
+
+	Selected string //announces the selection in Accessible mode, %s is the entry then %d its position and the count
+
+	Passphrase      string //masked prompt of a protected menu or option
+	WrongPassphrase string //shown after a rejected secret, %d is the number of attempts left
+	AccessDenied    string //shown once a protected entry's attempts run out, %s is its name
+
+	Countdown     string //auto-run countdown, %s is the entry then %d the seconds left
+	Locked        string //shown while locked after the idle timeout, %s is the timeout
+	BackTo        string //back to the previous menu, %s is its name
+	Choice        string //line mode prompt for the entry number
+	InvalidChoice string //line mode message for an unknown entry number, %s is what was typed
+
+	// Footer formats the footer line from the previous menu's name ("" in the home menu) and the exit label
+	// ("" if hidden), nil uses the default "←/esc back to <menu>, Exit (x)"
+	Footer func(previous string, exitLabel string) string
+}
+
+// DefaultStrings will return the built-in (English) messages, a starting point for translations
+func DefaultStrings() Strings {
+	return Strings{
+		Menu:     "Menu: %s",
+		Options:  "Options:",
+		SubMenus: "SubMenus:",
+		Welcome: "Welcome to go menu tree.\n" +
+			"%[1]c to move selection cursor.\n" +
+			"%[2]c/Enter/H%[4]stkey to choose.\n" +
+			"%[3]c/Esc to go back, %[5]s to Exit.\n" +
+			"` (backtick) to toggle redraw (small terminals may scramble)\n" +
+			"Press any key to start menu...",
+		Executing:        "Executing %s...",
+		Output:           "Output",
+		End:              "End",
+		PressAnyKey:      "(Press any key to continue)",
+		PressEnter:       "(Press Enter to continue)",
+		PressAnyKeyCopy:  "(Press %s to copy the output, any other key to continue)",
+		AutoReturn:       "(Returning to the menu in %s, press any key to return now)",
+		Copied:           "Copied %d lines to the clipboard",
+		CopyFailed:       "Copy failed: %v",
+		Disabled:         "%s is disabled: %s",
+		FunctionNotFound: "Error, function not found in Options map.",
+		MenuNotFound:     "Error, menu not found in subMenu map.",
+		ConfirmExit:      "Really exit? (y/x to confirm, any other key to stay)",
+		ExitCancelled:    "Exit cancelled: %v",
+		Running:          "running %s",
+		Failed:           "failed %s: %v",
+		Done:             "done %s",
+		PagerStatus:      "-- %d-%d of %d (%c scroll, space/b page, q back) --",
+		PagerEmpty:       "-- no output (q back) --",
+		WizardValue:      "Enter value: %s",
+		InvalidValue:     "Invalid value: %v",
+		OptionFailed:     "Error: %v",
+		ExitStatus:       "Exit status: %d",
+		MacroStep:        "[%d/%d] %s",
+		Favorites:        "Favorites",
+		Recent:           "Recent",
+		Search:           "Search: ",
+		SearchResults:    "Search: %s",
+		NoMatches:        "Nothing matches %q",
+		Loading:          "Loading…",
+		LoadFailed:       "Loading failed: %v",
+		Retry:            "Retry",
+		Environment:      " %s ",
+		ConfirmPhrase:    "Type %q to confirm: ",
+		NotConfirmed:     "Not confirmed, %s was not run",
+		AlreadyRunning:   "%s is already running",
+		Busy:             "Wait for %s to finish",
+		CoolingDown:      "%s can run again in %s",
+		Cooldown:         "again in %s",
+		RemoteNavigated:  "Remote: went to %s",
+		RemoteExecuted:   "Remote: ran %s",
+		TitleSeparator:   " > ",
+		LastAction:       "last: %s, %s ago",
+		ConfirmUndo:      "Undo %s? (y to confirm, any other key to cancel)",
+		UndoOption:       "undo %s",
+		Undone:           "Undid %s",
+		UndoFailed:       "Undo of %s failed: %v",
+		NothingToUndo:    "Nothing to undo",
+		TimedOut:         "*** %s timed out after %s ***",
+		Selected:         "Selected: %s, item %d of %d",
+		Passphrase:       "Passphrase: ",
+		WrongPassphrase:  "Wrong passphrase, %d attempts left",
+		AccessDenied:     "Access to %s denied",
+		Countdown:        "Running '%s' in %ds... (press any key to cancel)",
+		Locked:           "Locked after %s without input, press Enter to unlock",
+		BackTo:           "back to %s",
+		Choice:           "Enter choice: ",
+		InvalidChoice:    "Invalid choice: %s",
+	}
+}
+
+// footer formats the footer line with the configured Footer function or the default layout
+func (m *MenuTree) footer(previous string, exitLabel string) string {
+	if m.Strings.Footer != nil {
+		return m.Strings.Footer(previous, exitLabel)
+	}
+	switch {
+	case previous != "" && exitLabel != "":
+		return fmt.Sprintf(" %c/esc %s, %s ", leftArrow, fmt.Sprintf(m.Strings.BackTo, previous), exitLabel)
+	case previous != "":
+		return fmt.Sprintf(" %c/esc %s ", leftArrow, fmt.Sprintf(m.Strings.BackTo, previous))
+	default:
+		return exitLabel
+	}
+}
+
+// rule centers the label in a line of dashes as wide as the output banners
+func rule(label string) string {
+	fill := 34 - displayWidth(label) - 2
+	if fill < 2 {
+		fill = 2
+	}
+	return strings.Repeat("-", fill/2) + " " + label + " " + strings.Repeat("-", fill-fill/2)
+}
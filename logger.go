@@ -0,0 +1,8 @@
+package gomenutree
+
+// debug passes an internal event with its key/value pairs to the logger set with SetLogger (Go 1.21+), if any
+func (m *MenuTree) debug(msg string, args ...interface{}) {
+	if m.debugFunc != nil {
+		m.debugFunc(msg, args...)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build go1.21
+// +build go1.21
+
+package gomenutree
+
+import (
+	"log/slog"
+)
+
+// SetLogger will log navigation, renders, input errors and terminal state changes to the structured logger at debug
+// level (useful for diagnosing redraw problems), nil stops logging
+func (m *MenuTree) SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		m.debugFunc = nil
+		return
+	}
+	m.debugFunc = func(msg string, args ...interface{}) {
+		logger.Debug(msg, args...)
+	}
+}
@@ -0,0 +1,117 @@
+package gomenutree
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+type (
+	// macroStep is an option run as part of a macro
+	macroStep struct {
+		menu *Menu
+		name string
+	}
+
+	// macroRecording collects the options run while recording a macro
+	macroRecording struct {
+		menu  *Menu
+		name  string
+		count int
+		steps []string
+	}
+)
+
+// AddMacroOption will add an option to the menu that runs other options in order, with their output combined; each
+// step is an option name in the same menu, or a "/" separated path to an option in another menu (e.g.
+// "Services/Web/restart", optionally starting with the home menu name); steps are looked up when the macro runs,
+// so renamed or deleted options are skipped, and options taking arguments, leading to submenus, protected by a
+// passphrase or needing a typed confirmation can not be steps; the macro stops at the first step that is blocked (see
+// SetCooldown) or fails
+func (m *MenuTree) AddMacroOption(menu *Menu, name string, steps ...string) error {
+	resolved := make([]macroStep, 0, len(steps))
+	for _, step := range steps {
+		s, e := m.resolveStep(menu, step)
+		if e != nil {
+			return fmt.Errorf("gomenutree: macro %s: %w", name, e)
+		}
+		resolved = append(resolved, s)
+	}
+	menu.addOption(name, &option{function: func() {
+		for i, s := range resolved {
+			o, ok := s.menu.options[s.name]
+			if !ok || !chainable(o) {
+				continue
+			}
+			fmt.Fprintln(m.Writer(), fmt.Sprintf(m.Strings.MacroStep, i+1, len(resolved), s.name))
+			if reason := m.blockReason(s.name, o); reason != "" {
+				fmt.Fprintln(m.Writer(), reason)
+				return
+			}
+			e := m.audited(s.menu, s.name, func() error {
+				return m.invoke(s.name, o, nil, os.Stdin, true)
+			})
+			if e != nil {
+				m.reportFailure(s.name, o, e)
+				return
+			}
+		}
+	}})
+	return nil
+}
+
+// RecordMacro will start recording the next count options the user runs; once recorded, they are added to the menu
+// as a macro option with the given name (see AddMacroOption), a count of 0 or less stops a recording in progress
+func (m *MenuTree) RecordMacro(menu *Menu, name string, count int) {
+	m.macro = nil
+	if count > 0 {
+		m.macro = &macroRecording{menu: menu, name: name, count: count}
+	}
+}
+
+// RecordingMacro will report whether a macro is being recorded
+func (m *MenuTree) RecordingMacro() bool {
+	return m.macro != nil
+}
+
+// recordMacroStep adds the option run to the macro being recorded, creating the macro once it has enough steps
+func (m *MenuTree) recordMacroStep(menu *Menu, name string, o *option) {
+	if m.macro == nil || !chainable(o) {
+		return
+	}
+	path := append(m.menuPath(menu), name)
+	m.macro.steps = append(m.macro.steps, strings.Join(path, "/"))
+	if len(m.macro.steps) < m.macro.count {
+		return
+	}
+	recorded := m.macro
+	m.macro = nil
+	if e := m.AddMacroOption(recorded.menu, recorded.name, recorded.steps...); e != nil {
+		m.debug("macro not recorded", "name", recorded.name, "error", e)
+	}
+}
+
+// resolveStep finds the menu and option a macro step refers to
+func (m *MenuTree) resolveStep(menu *Menu, step string) (macroStep, error) {
+	if i := strings.LastIndex(step, "/"); i >= 0 {
+		var e error
+		if menu, _, e = m.resolvePath(step[:i]); e != nil {
+			return macroStep{}, e
+		}
+		step = step[i+1:]
+	}
+	o, ok := menu.options[step]
+	if !ok {
+		return macroStep{}, fmt.Errorf("no option %q in menu %q", step, menu.name)
+	}
+	if !chainable(o) {
+		return macroStep{}, fmt.Errorf("option %q in menu %q can not be chained", step, menu.name)
+	}
+	return macroStep{menu: menu, name: step}, nil
+}
+
+// chainable reports whether the option can run as a macro step (not a separator, submenu, search result, option with
+// arguments, protected or destructive option)
+func chainable(o *option) bool {
+	return !o.separator && o.subMenu == nil && o.jump == nil && o.args == nil && o.gate == nil && o.confirmPhrase == ""
+}
@@ -0,0 +1,59 @@
+package gomenutree
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+type (
+	// Metrics receives counters and timings of the options used (e.g. to export them to Prometheus); async options
+	// report from their goroutine, so implementations must be safe for concurrent use
+	Metrics interface {
+		IncSelection(menu string, option string)                                       //an option was chosen and starts running
+		ObserveDuration(menu string, option string, duration time.Duration, err error) //an option finished running
+	}
+
+	// expvarMetrics publishes the metrics as expvar maps keyed "menu/option"
+	expvarMetrics struct {
+		selections *expvar.Map
+		errors     *expvar.Map
+		seconds    *expvar.Map
+	}
+)
+
+var expvarMu sync.Mutex //guards publishing the expvar maps
+
+// ExpvarMetrics will return metrics published with expvar under the name (served as JSON at /debug/vars once
+// expvar's handler is registered), as a map holding "selections", "errors" and "seconds" (the total time run), each
+// keyed "menu/option"; trees given the same name share the maps
+func ExpvarMetrics(name string) Metrics {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	root, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		root = expvar.NewMap(name)
+	}
+	child := func(key string) *expvar.Map {
+		if c, ok := root.Get(key).(*expvar.Map); ok {
+			return c
+		}
+		c := new(expvar.Map).Init()
+		root.Set(key, c)
+		return c
+	}
+	return &expvarMetrics{selections: child("selections"), errors: child("errors"), seconds: child("seconds")}
+}
+
+// IncSelection implements Metrics
+func (e *expvarMetrics) IncSelection(menu string, option string) {
+	e.selections.Add(menu+"/"+option, 1)
+}
+
+// ObserveDuration implements Metrics
+func (e *expvarMetrics) ObserveDuration(menu string, option string, duration time.Duration, err error) {
+	e.seconds.AddFloat(menu+"/"+option, duration.Seconds())
+	if err != nil {
+		e.errors.Add(menu+"/"+option, 1)
+	}
+}
@@ -0,0 +1,63 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PushModalMenu will show the menu as a popup over the current one (e.g. "choose a region" from within an option or a
+// key binding) and return the name of the option chosen, without running it, once the popup closes and the menu
+// beneath is drawn again as it was; ErrPickCancelled is returned if the user exits or goes back (or the terminal
+// error). Where the menu is not drawn in place (line mode, Accessible, while an option runs) the popup is written
+// below it instead, and when nothing is displayed it works like Pick
+func (m *MenuTree) PushModalMenu(menu *Menu) (string, error) {
+	if !m.displaying {
+		return m.Pick(menu)
+	}
+	currentMenu, previousMenu := m.currentMenu, m.previousMenu
+	beneath, beneathPrev := m.beneath, m.beneathPrev
+	under := m.state(currentMenu)
+	if m.ownsTerminal() && !m.inOption {
+		m.beneath, m.beneathPrev = currentMenu, previousMenu
+		m.state(menu).lastRenderLines = under.lastRenderLines
+	} else {
+		m.state(menu).lastRenderLines = 0
+	}
+	m.debug("modal menu", "menu", menu.name, "over", currentMenu.name, "overlay", m.beneath != nil)
+	m.currentMenu, m.previousMenu = menu, nil
+	m.initSelection()
+	m.render()
+	name, e := m.choose(menu)
+	m.currentMenu, m.previousMenu = currentMenu, previousMenu
+	m.beneath, m.beneathPrev = beneath, beneathPrev
+	under.lastRenderLines = 0
+	if m.Redraw && m.ownsTerminal() && !m.inOption {
+		under.lastRenderLines = m.state(menu).lastRenderLines //draw the menu over the popup
+	} else {
+		fmt.Fprintln(m.out)
+	}
+	if !m.inOption {
+		m.render()
+	}
+	return name, e
+}
+
+// overlaidFrame returns the menu beneath a modal menu with the modal's frame drawn centered over it (unstyled, so the
+// popup stands out)
+func (m *MenuTree) overlaidFrame(frame string) string {
+	currentMenu, previousMenu := m.currentMenu, m.previousMenu
+	m.currentMenu, m.previousMenu = m.beneath, m.beneathPrev
+	under := m.frame()
+	m.currentMenu, m.previousMenu = currentMenu, previousMenu
+	box := strings.Split(strings.TrimPrefix(frame, "\n"), "\n")
+	width := 0
+	for _, l := range box {
+		if w := displayWidth(l); w > width {
+			width = w
+		}
+	}
+	for i, l := range box {
+		box[i] = l + strings.Repeat(" ", width-displayWidth(l))
+	}
+	return "\n" + strings.Join(overlay(strings.Split(strings.TrimPrefix(under, "\n"), "\n"), box), "\n")
+}
@@ -0,0 +1,105 @@
+package gomenutree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FindMenu will return the first menu in the tree (searching breadth first from the home menu) with the given name,
+// or nil if there is none
+func (m *MenuTree) FindMenu(name string) *Menu {
+	seen := map[*Menu]bool{m.homeMenu: true}
+	queue := []*Menu{m.homeMenu}
+	for len(queue) > 0 {
+		menu := queue[0]
+		queue = queue[1:]
+		if menu.name == name {
+			return menu
+		}
+		for _, sm := range m.children(menu) {
+			if !seen[sm] {
+				seen[sm] = true
+				queue = append(queue, sm)
+			}
+		}
+	}
+	return nil
+}
+
+// Navigate will switch to the menu at the given "/" separated path of submenu names (e.g. "Settings/Network",
+// optionally starting with the home menu name), with "back" leading to the path's parent menu;
+// names are matched exactly first, then case insensitively
+func (m *MenuTree) Navigate(path string) error {
+	menu, parent, e := m.resolvePath(path)
+	if e != nil {
+		return e
+	}
+	m.changeMenu(menu, parent)
+	return nil
+}
+
+// resolvePath walks the path from the home menu, returning the menu it leads to and that menu's parent
+func (m *MenuTree) resolvePath(path string) (*Menu, *Menu, error) {
+	var parent *Menu
+	menu := m.homeMenu
+	for _, p := range m.pathParts(path) {
+		next := m.subMenuNamed(menu, p)
+		if next == nil {
+			return nil, nil, fmt.Errorf("gomenutree: no submenu %q in menu %q (path %q)", p, menu.name, path)
+		}
+		parent, menu = menu, next
+	}
+	return menu, parent, nil
+}
+
+// openPath walks the path from the home menu like resolvePath, but only through submenus the user could open from the
+// menu: it stops before the first submenu that is hidden or protected (an error wrapping errProtected), returning the
+// menu reached so far and that menu's parent along with the error
+func (m *MenuTree) openPath(path string) (*Menu, *Menu, error) {
+	var parent *Menu
+	menu := m.homeMenu
+	for _, p := range m.pathParts(path) {
+		next := m.subMenuNamed(menu, p)
+		if next == nil || !m.visibleSubMenu(menu, next) {
+			return menu, parent, fmt.Errorf("gomenutree: no submenu %q in menu %q (path %q)", p, menu.name, path)
+		}
+		if next.gate != nil {
+			return menu, parent, fmt.Errorf("%w: %q (path %q)", errProtected, next.name, path)
+		}
+		parent, menu = menu, next
+	}
+	return menu, parent, nil
+}
+
+// errProtected reports a protected menu on a path opened without the terminal (see openPath)
+var errProtected = errors.New("gomenutree: protected menu")
+
+// pathParts splits the "/" separated path into submenu names, without the home menu's name if it starts with it
+func (m *MenuTree) pathParts(path string) []string {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) > 0 && (parts[0] == m.homeMenu.name || strings.EqualFold(parts[0], m.homeMenu.name)) {
+		parts = parts[1:]
+	}
+	return parts
+}
+
+// subMenuNamed returns the submenu of the menu with the given name (exact match first, then case insensitive)
+func (m *MenuTree) subMenuNamed(menu *Menu, name string) *Menu {
+	for _, sm := range m.children(menu) {
+		if sm.name == name {
+			return sm
+		}
+	}
+	for _, sm := range m.children(menu) {
+		if strings.EqualFold(sm.name, name) {
+			return sm
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// navigationRows returns the key events of the back and exit rows listed after the current menu's entries
+// (none unless NavigationRows is set; back only below the home menu, exit unless HideExit)
+func (m *MenuTree) navigationRows() []string {
+	if !m.NavigationRows {
+		return nil
+	}
+	var rows []string
+	if m.previousMenu != nil {
+		rows = append(rows, "BACK")
+	}
+	if !m.HideExit {
+		rows = append(rows, "EXIT")
+	}
+	return rows
+}
+
+// entryCount returns the number of selectable positions in the current menu: options, submenus, then back/exit rows
+func (m *MenuTree) entryCount() int {
+	return len(m.currentMenu.optionsOrder) + len(m.subMenuMap[m.currentMenu]) + len(m.navigationRows())
+}
+
+// navigationLines draws the back and exit rows, marking the selected one
+func (m *MenuTree) navigationLines(menuStyle Style) []string {
+	var lines []string
+	first := len(m.currentMenu.optionsOrder) + len(m.subMenuMap[m.currentMenu])
+	for i, row := range m.navigationRows() {
+		label := underlineHotKey(m.ExitLabel, "x", menuStyle.HotKey)
+		if row == "BACK" {
+			label = fmt.Sprintf("%c %s", leftArrow, strings.TrimSpace(fmt.Sprintf(m.Strings.BackTo, m.previousMenu.name)))
+		}
+		if first+i == m.state(m.currentMenu).selection {
+			lines = append(lines, ">"+apply(menuStyle.Selected, label))
+		} else {
+			lines = append(lines, " "+apply(menuStyle.Label, label))
+		}
+	}
+	return lines
+}
+
+// navigationRow returns the key event of the back or exit row at index, "" if the index is not one of them
+func (m *MenuTree) navigationRow(index int) string {
+	rows := m.navigationRows()
+	if i := index - len(m.currentMenu.optionsOrder) - len(m.subMenuMap[m.currentMenu]); i >= 0 && i < len(rows) {
+		return rows[i]
+	}
+	return ""
+}
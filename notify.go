@@ -0,0 +1,103 @@
+package gomenutree
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ttacon/chalk"
+)
+
+type (
+	// Level is the severity of a notification, setting its color
+	Level int
+
+	// notification is a transient message shown in the menu frame (see Notify)
+	notification struct {
+		message string
+		level   Level
+	}
+)
+
+const (
+	LevelInfo    Level = iota // cyan
+	LevelSuccess              // green
+	LevelWarning              // yellow
+	LevelError                // red
+)
+
+// String will return the name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelInfo:
+		return "info"
+	case LevelSuccess:
+		return "success"
+	case LevelWarning:
+		return "warning"
+	case LevelError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// color returns the color the level's notifications are drawn in
+func (l Level) color() chalk.Color {
+	switch l {
+	case LevelSuccess:
+		return chalk.Green
+	case LevelWarning:
+		return chalk.Yellow
+	case LevelError:
+		return chalk.Red
+	}
+	return chalk.Cyan
+}
+
+// Notify will show the message in the menu frame, above the footer, until the ttl has passed (0 keeps it) or a key is
+// pressed, replacing any notification shown; safe to call from other goroutines (e.g. "deployment finished"), the
+// menu is redrawn right away while waiting for input
+func (m *MenuTree) Notify(message string, level Level, ttl time.Duration) {
+	n := &notification{message: message, level: level}
+	m.bgMu.Lock()
+	m.notice = n
+	m.bgMu.Unlock()
+	m.refresh()
+	if ttl <= 0 {
+		return
+	}
+	time.AfterFunc(ttl, func() {
+		m.bgMu.Lock()
+		expired := m.notice == n
+		if expired {
+			m.notice = nil
+		}
+		m.bgMu.Unlock()
+		if expired {
+			m.refresh()
+		}
+	})
+}
+
+// clearNotice removes the notification (once a key is pressed), reporting whether there was one
+func (m *MenuTree) clearNotice() bool {
+	m.bgMu.Lock()
+	defer m.bgMu.Unlock()
+	shown := m.notice != nil
+	m.notice = nil
+	return shown
+}
+
+// noticeLine returns the colored notification line, kept within the terminal width ("" if there is none)
+func (m *MenuTree) noticeLine() string {
+	m.bgMu.Lock()
+	n := m.notice
+	m.bgMu.Unlock()
+	if n == nil {
+		return ""
+	}
+	message := sanitize(strings.Replace(strings.Replace(n.message, "\r", "", -1), "\n", " ", -1))
+	if m.width > 0 && displayWidth(message) > m.width-2 {
+		message = truncateStyled(message, m.width-2)
+	}
+	return " " + n.level.color().Color(message)
+}
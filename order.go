@@ -0,0 +1,81 @@
+package gomenutree
+
+import (
+	"sort"
+)
+
+// InsertOptionAt will add a named option at the given position in the list of menu selections (0 is first, an index
+// past the end appends), replacing an existing option of the same name
+func (m *Menu) InsertOptionAt(index int, name string, function func()) {
+	m.addOption(name, &option{function: function})
+	m.MoveOption(name, index)
+}
+
+// MoveOption will move the named option to the given position in the list of menu selections (0 is first, an index
+// past the end moves it last)
+func (m *Menu) MoveOption(name string, index int) {
+	for i, n := range m.optionsOrder {
+		if n == name {
+			m.optionsOrder = append(m.optionsOrder[:i], m.optionsOrder[i+1:]...)
+			index = clampIndex(index, len(m.optionsOrder))
+			m.optionsOrder = append(m.optionsOrder[:index], append([]string{name}, m.optionsOrder[index:]...)...)
+			return
+		}
+	}
+}
+
+// SortOptions will sort the options by name with the less function (keeping the order of equal names); separators
+// stay in place, so each group of options is sorted on its own
+func (m *Menu) SortOptions(less func(a, b string) bool) {
+	start := 0
+	for i := 0; i <= len(m.optionsOrder); i++ {
+		if i < len(m.optionsOrder) && !m.options[m.optionsOrder[i]].separator {
+			continue
+		}
+		group := m.optionsOrder[start:i]
+		sort.SliceStable(group, func(a, b int) bool {
+			return less(group[a], group[b])
+		})
+		start = i + 1
+	}
+}
+
+// InsertSubMenuAt will add the child menu at the given position in the list of submenu selections in the parent menu
+// (0 is first, an index past the end appends)
+func (m *MenuTree) InsertSubMenuAt(parentMenu *Menu, index int, childMenu *Menu) {
+	smm := m.subMenuMap[parentMenu]
+	index = clampIndex(index, len(smm))
+	m.subMenuMap[parentMenu] = append(smm[:index:index], append([]*Menu{childMenu}, smm[index:]...)...)
+}
+
+// MoveSubMenu will move the child menu to the given position in the list of submenu selections in the parent menu
+// (0 is first, an index past the end moves it last)
+func (m *MenuTree) MoveSubMenu(parentMenu *Menu, childMenu *Menu, index int) {
+	for _, sm := range m.subMenuMap[parentMenu] {
+		if sm == childMenu {
+			m.DeleteSubMenu(parentMenu, childMenu)
+			m.InsertSubMenuAt(parentMenu, index, childMenu)
+			return
+		}
+	}
+}
+
+// SortSubMenus will sort the submenus of the parent menu by name with the less function (keeping the order of
+// equal names)
+func (m *MenuTree) SortSubMenus(parentMenu *Menu, less func(a, b string) bool) {
+	smm := m.subMenuMap[parentMenu]
+	sort.SliceStable(smm, func(a, b int) bool {
+		return less(smm[a].name, smm[b].name)
+	})
+}
+
+// clampIndex keeps an insert position within 0 and length
+func clampIndex(index int, length int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
@@ -0,0 +1,101 @@
+package gomenutree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+type (
+	// outputArea keeps the lines written to OutputWriter within a bounded number of rows while an option runs
+	outputArea struct {
+		mu      sync.Mutex
+		screen  io.Writer //nil while lines are written as they are
+		rows    int
+		width   int
+		lines   []string
+		partial string
+		shown   int //rows drawn so far
+	}
+
+	// outputWriter writes to the menu tree's output area
+	outputWriter struct {
+		m *MenuTree
+	}
+)
+
+// OutputWriter will return a writer option functions can report progress through (also from other goroutines):
+// while an option runs, the last OutputLines lines written are redrawn in place below its banner, older ones scrolling
+// out, so the menu frame above stays intact; in line mode, in the pager or when output is captured for CopyKey they
+// are written as they are. Mixing it with printing to stdout in the same option scrambles the output
+func (m *MenuTree) OutputWriter() io.Writer {
+	return outputWriter{m: m}
+}
+
+// Write implements io.Writer
+func (w outputWriter) Write(p []byte) (int, error) {
+	return w.m.output.write(p, w.m.Writer())
+}
+
+// boundOutput starts keeping the output area within OutputLines rows, if the menu draws on the terminal itself
+func (m *MenuTree) boundOutput() {
+	if m.OutputLines <= 0 || m.CopyKey != "" || !m.ownsTerminal() {
+		return
+	}
+	a := m.output
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.screen, a.rows, a.width = m.out, m.OutputLines, m.width
+}
+
+// unboundOutput ends the bounded output area once the option has returned, leaving its last lines on the screen
+func (m *MenuTree) unboundOutput() {
+	a := m.output
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.screen, a.lines, a.partial, a.shown = nil, nil, "", 0
+}
+
+// write adds the text to the area and redraws it, or writes it to out if the area is not bounded
+func (a *outputArea) write(p []byte, out io.Writer) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.screen == nil {
+		return out.Write(p)
+	}
+	parts := strings.Split(a.partial+string(p), "\n")
+	a.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		a.lines = append(a.lines, strings.TrimRight(line, "\r"))
+	}
+	if len(a.lines) > a.rows {
+		a.lines = append(a.lines[:0], a.lines[len(a.lines)-a.rows:]...)
+	}
+	a.draw()
+	return len(p), nil
+}
+
+// draw redraws the area's rows in place, the cursor ending below them
+func (a *outputArea) draw() {
+	visible := a.lines
+	if a.partial != "" {
+		visible = append(visible[:len(visible):len(visible)], a.partial)
+	}
+	if len(visible) > a.rows {
+		visible = visible[len(visible)-a.rows:]
+	}
+	var sb strings.Builder
+	if a.shown > 0 {
+		fmt.Fprintf(&sb, "\033[%dA", a.shown)
+	}
+	for _, line := range visible {
+		line = sanitize(strings.TrimRight(line, "\r"))
+		if a.width > 0 && displayWidth(line) > a.width {
+			line = truncateStyled(line, a.width)
+		}
+		sb.WriteString("\r\033[K" + line + "\n")
+	}
+	a.shown = len(visible)
+	fmt.Fprint(a.screen, sb.String())
+}
@@ -0,0 +1,176 @@
+package gomenutree
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Overflow selects how menu lines wider than the terminal are handled
+type Overflow int
+
+const (
+	// OverflowTruncate cuts long lines short with an ellipsis
+	OverflowTruncate Overflow = iota
+	// OverflowWrap soft-wraps long lines (at a space where possible) onto indented continuation lines
+	OverflowWrap
+	// OverflowNone leaves long lines alone (the terminal wraps them)
+	OverflowNone
+)
+
+// ellipsis marks a truncated line
+const ellipsis = "…"
+
+// detectSize will pick up the local terminal dimensions before each render, unless they were set with SetSize (capped
+// on a serial console)
+func (m *MenuTree) detectSize() {
+	defer m.capSize()
+	if m.sizeSet || m.in != nil {
+		return
+	}
+	width, height := terminalSize()
+	if width != m.width || height != m.height {
+		m.debug("terminal size changed", "width", width, "height", height)
+	}
+	m.width, m.height = width, height
+}
+
+// fit keeps the menu lines within the given width as configured by Overflow
+func (m *MenuTree) fit(lines []string, width int) []string {
+	if m.width <= 0 || width < 8 || m.Overflow == OverflowNone {
+		return lines
+	}
+	var fitted []string
+	for i, l := range lines {
+		if displayWidth(l) <= width {
+			if fitted != nil {
+				fitted = append(fitted, l)
+			}
+			continue
+		}
+		if fitted == nil {
+			fitted = append(make([]string, 0, len(lines)+8), lines[:i]...)
+		}
+		if m.Overflow == OverflowWrap {
+			fitted = append(fitted, wrapStyled(l, width)...)
+		} else {
+			fitted = append(fitted, truncateStyled(l, width))
+		}
+	}
+	if fitted == nil {
+		return lines
+	}
+	return fitted
+}
+
+// token is a piece of styled text: a single rune or a (zero width) escape sequence
+type token struct {
+	text  string
+	width int
+}
+
+// tokenize splits styled text into runes and escape sequences
+func tokenize(text string) []token {
+	var tokens []token
+	for text != "" {
+		if loc := ansiPattern.FindStringIndex(text); loc != nil && loc[0] == 0 {
+			tokens = append(tokens, token{text: text[:loc[1]]})
+			text = text[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		tokens = append(tokens, token{text: text[:size], width: runeWidth(r)})
+		text = text[size:]
+	}
+	return tokens
+}
+
+// truncateStyled cuts the text down to width columns ending in an ellipsis, keeping its escape sequences
+// (and resetting the style if any were cut off)
+func truncateStyled(text string, width int) string {
+	var sb strings.Builder
+	used, styled := 0, false
+	for _, t := range tokenize(text) {
+		if t.width == 0 {
+			styled = styled || strings.HasPrefix(t.text, "\x1b")
+			sb.WriteString(t.text)
+			continue
+		}
+		if used+t.width > width-displayWidth(ellipsis) {
+			break
+		}
+		used += t.width
+		sb.WriteString(t.text)
+	}
+	sb.WriteString(ellipsis)
+	if styled {
+		sb.WriteString("\x1b[0m")
+	}
+	return sb.String()
+}
+
+// wrapStyled splits the text into lines of at most width columns, breaking at the last space where possible;
+// continuation lines are indented one past the original indent and pick the style back up
+func wrapStyled(text string, width int) []string {
+	tokens := tokenize(text)
+	indent := 0
+	for indent < len(tokens) && tokens[indent].text == " " {
+		indent++
+	}
+	prefix := strings.Repeat(" ", indent+1)
+	if indent+1 >= width/2 {
+		prefix = " "
+	}
+	var lines []string
+	var active []string
+	start, used := 0, 0
+	for i := 0; i < len(tokens); i++ {
+		if used+tokens[i].width <= width {
+			used += tokens[i].width
+			continue
+		}
+		end := i
+		for j := i - 1; j > start; j-- {
+			if tokens[j].text == " " && j > indent {
+				end = j
+				break
+			}
+		}
+		line, next := joinTokens(tokens[start:end], active)
+		if len(lines) > 0 {
+			line = prefix + line
+		}
+		lines = append(lines, line)
+		active = next
+		if end < len(tokens) && tokens[end].text == " " {
+			end++
+		}
+		start, i = end, end-1
+		used = displayWidth(prefix)
+	}
+	line, _ := joinTokens(tokens[start:], active)
+	if len(lines) > 0 {
+		line = prefix + line
+	}
+	return append(lines, line)
+}
+
+// joinTokens writes out the tokens after re-applying the active escape sequences, resetting the style at the end
+// if any are left active; it returns the escape sequences active after the tokens
+func joinTokens(tokens []token, active []string) (string, []string) {
+	var sb strings.Builder
+	sb.WriteString(strings.Join(active, ""))
+	for _, t := range tokens {
+		if t.width == 0 && strings.HasPrefix(t.text, "\x1b") {
+			if t.text == "\x1b[0m" {
+				active = nil
+			} else {
+				active = append(active, t.text)
+			}
+		}
+		sb.WriteString(t.text)
+	}
+	if len(active) > 0 {
+		sb.WriteString("\x1b[0m")
+	}
+	return sb.String(), active
+}
@@ -0,0 +1,157 @@
+package gomenutree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SetOptionPager will enable or disable capturing the named option's output into the built-in pager
+// (MenuTree.Pager enables it for every option)
+func (m *Menu) SetOptionPager(name string, enabled bool) {
+	if o, ok := m.options[name]; ok {
+		o.pager = enabled
+	}
+}
+
+// syncWriter serializes the writes to w (option functions may write from several goroutines)
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write implements io.Writer
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// capture runs the function with its output (what it writes to Writer, and on the local terminal stdout and stderr)
+// redirected into a buffer, returning its lines
+func (m *MenuTree) capture(function func()) []string {
+	return m.captureTee(function, nil)
+}
+
+// captureTee captures like capture, while also copying the output to tee as it is written (unless nil)
+func (m *MenuTree) captureTee(function func(), tee io.Writer) []string {
+	return m.collect(function, tee, m.capturesStdio())
+}
+
+// captureWriter captures only what the function writes to Writer (or WriterFrom), never stdout and stderr, for options
+// run off the event loop (see runDetached) while the terminal may still be printing to them
+func (m *MenuTree) captureWriter(function func()) []string {
+	return m.collect(function, nil, false)
+}
+
+// collect runs the function with Writer returning a buffer (also copying to tee unless nil), and stdout and stderr
+// redirected to it too if stdio is set, returning the lines written
+func (m *MenuTree) collect(function func(), tee io.Writer, stdio bool) []string {
+	var buf bytes.Buffer
+	var dst io.Writer = &buf
+	if tee != nil {
+		dst = io.MultiWriter(&buf, tee)
+	}
+	w := &syncWriter{w: dst}
+	func() {
+		if stdio {
+			defer redirectStdio(w)()
+		}
+		m.withOptionOut(w, function)
+	}()
+	w.mu.Lock()
+	text := strings.TrimRight(strings.Replace(buf.String(), "\r\n", "\n", -1), "\n")
+	w.mu.Unlock()
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// capturesStdio reports whether capturing option output also redirects the process's stdout and stderr: only for a
+// tree on the local terminal, as sessions (NewSession, SetIO) run alongside others in the same process and would
+// capture their output too (their options print to WriterFrom)
+func (m *MenuTree) capturesStdio() bool {
+	return m.in == nil && !m.session
+}
+
+// redirectStdio points stdout and stderr at a pipe copied to w, returning the function restoring them once everything
+// written has been copied
+func redirectStdio(w io.Writer) func() {
+	r, pw, e := os.Pipe()
+	if e != nil {
+		panic(e)
+	}
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(w, r)
+		close(done)
+	}()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = pw, pw
+	return func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		_ = pw.Close()
+		<-done
+		_ = r.Close()
+	}
+}
+
+// page shows the lines in a scrollable region until the user returns to the menu
+// (up/down scroll a line, space/enter or b page down or up, q/esc/left return)
+func (m *MenuTree) page(lines []string) {
+	size := 20
+	if m.height > 2 {
+		size = m.height - 2
+	}
+	if size > len(lines) {
+		size = len(lines)
+	}
+	top, drawn := 0, 0
+	for {
+		if drawn > 0 {
+			fmt.Fprintf(m.out, "\033[%dA\r\033[J", drawn)
+		}
+		var sb strings.Builder
+		for _, l := range lines[top : top+size] {
+			if m.width > 0 && displayWidth(l) > m.width {
+				l = truncate(l, m.width)
+			}
+			sb.WriteString(l + "\n")
+		}
+		sb.WriteString(fmt.Sprintf(m.Strings.PagerStatus, top+1, top+size, len(lines), upDownArrow))
+		if len(lines) == 0 {
+			sb.Reset()
+			sb.WriteString(m.Strings.PagerEmpty)
+		}
+		frame := sb.String()
+		fmt.Fprint(m.out, frame)
+		drawn = m.rows(frame)
+		switch strings.ToUpper(m.getInput()) {
+		case "UP":
+			top--
+		case "DOWN":
+			top++
+		case " ", "ENTER", "PGDN":
+			top += size
+		case "B", "PGUP":
+			top -= size
+		case "HOME":
+			top = 0
+		case "END":
+			top = len(lines)
+		case "Q", "BACK", "LEFT", "EXIT", "ERROR", "INTERRUPT":
+			fmt.Fprintln(m.out)
+			return
+		}
+		if top > len(lines)-size {
+			top = len(lines) - size
+		}
+		if top < 0 {
+			top = 0
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package gomenutree
+
+import (
+	"fmt"
+	"time"
+)
+
+// PauseMode selects what happens once an option's output has been shown
+type PauseMode int
+
+const (
+	// PauseForKey waits for a key before returning to the menu
+	PauseForKey PauseMode = iota
+	// PauseNone returns to the menu straight away (e.g. for toggles and options without output)
+	PauseNone
+	// PauseTimed returns to the menu after the auto-return delay, or sooner when a key is pressed
+	PauseTimed
+)
+
+// SetOptionPause will set what happens after the named option runs, overriding MenuTree.Pause (the delay is used
+// with PauseTimed, 0 uses MenuTree.AutoReturn)
+func (m *Menu) SetOptionPause(name string, mode PauseMode, delay time.Duration) {
+	if o, ok := m.options[name]; ok {
+		o.pause = &mode
+		o.pauseDelay = delay
+	}
+}
+
+// pauseAfter waits (or not) after the option's output as configured for the option or the tree
+func (m *MenuTree) pauseAfter(o *option) {
+	mode, delay := m.Pause, m.AutoReturn
+	if o != nil && o.pause != nil {
+		mode = *o.pause
+		if o.pauseDelay > 0 {
+			delay = o.pauseDelay
+		}
+	}
+	if mode != PauseNone {
+		m.drainInput()
+	}
+	switch {
+	case mode == PauseNone:
+	case mode == PauseTimed && delay > 0 && !m.hosted:
+		fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.AutoReturn, delay))
+		m.getInputWithin(delay)
+	default:
+		m.waitToContinue()
+	}
+}
@@ -0,0 +1,76 @@
+package gomenutree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPickCancelled is returned by Pick when the user exits or goes back without choosing
+var ErrPickCancelled = errors.New("gomenutree: pick cancelled")
+
+// Pick will show the menu on its own with the default configuration and return the name of the option chosen,
+// without running it (see MenuTree.Pick)
+func (m *Menu) Pick() (string, error) {
+	return NewMenuTree(m).Pick(m)
+}
+
+// Pick will show the menu once (without the welcome screen) and return the name of the option chosen, without running
+// it, leaving the terminal as it was; ErrPickCancelled is returned if the user exits or goes back (or the terminal
+// error)
+func (m *MenuTree) Pick(menu *Menu) (string, error) {
+	currentMenu, previousMenu := m.currentMenu, m.previousMenu
+	defer func() {
+		m.currentMenu, m.previousMenu = currentMenu, previousMenu
+		fmt.Fprintln(m.out)
+		if !m.displaying {
+			fmt.Fprintf(m.out, "\033[?25h")
+		}
+	}()
+	m.hideCursor()
+	m.currentMenu, m.previousMenu = menu, nil
+	m.state(menu).lastRenderLines = 0
+	m.initSelection()
+	m.render()
+	return m.choose(menu)
+}
+
+// choose will move the selection in the menu shown as the current one until an option is chosen, returning its name,
+// or ErrPickCancelled if the user exits or goes back (or the terminal error)
+func (m *MenuTree) choose(menu *Menu) (string, error) {
+	for {
+		input := strings.ToUpper(m.getInput())
+		index := -1
+		switch input {
+		case "UP":
+			m.moveSelection(-1)
+		case "DOWN":
+			m.moveSelection(1)
+		case "HOME", "END":
+			m.selectEdge(input == "HOME")
+		case "PGUP":
+			m.movePage(-1)
+		case "PGDN":
+			m.movePage(1)
+		case "ENTER", "RIGHT":
+			index = m.state(menu).selection
+		case "ERROR":
+			return "", m.inputErr
+		case "EXIT", "INTERRUPT", "BACK", "LEFT":
+			return "", ErrPickCancelled
+		default:
+			hk, ok := m.state(menu).hotKeys[input]
+			if !ok {
+				continue
+			}
+			m.state(menu).selection = hk
+			index = hk
+		}
+		if index >= 0 && index < len(menu.optionsOrder) {
+			if o := menu.options[menu.optionsOrder[index]]; !o.separator && !o.disabled {
+				return menu.optionsOrder[index], nil
+			}
+		}
+		m.render()
+	}
+}
@@ -0,0 +1,63 @@
+package gomenutree
+
+import "fmt"
+
+type (
+	// Plugin contributes menus to a host application's menu tree from a separate package (e.g. a "Kubernetes" or
+	// "Database" plugin), registered with MenuTree.Register
+	Plugin interface {
+		Name() string              // unique name of the plugin
+		Init(tree *MenuTree) error // called once when registered, e.g. to add exit hooks (OnExit) or key bindings (BindKey)
+		Menus() []*Menu            // menus added as submenus of the home menu, after Init
+	}
+
+	// registeredPlugin is a plugin along with the menus it added
+	registeredPlugin struct {
+		plugin Plugin
+		menus  []*Menu
+	}
+)
+
+// Register will initialize the plugin and add its menus as submenus of the home menu; an error is returned (and
+// nothing added) if a plugin with the same name is already registered or Init fails
+func (m *MenuTree) Register(plugin Plugin) error {
+	name := plugin.Name()
+	for _, p := range m.plugins {
+		if p.plugin.Name() == name {
+			return fmt.Errorf("gomenutree: plugin %q already registered", name)
+		}
+	}
+	if e := plugin.Init(m); e != nil {
+		return fmt.Errorf("gomenutree: plugin %q: %w", name, e)
+	}
+	menus := plugin.Menus()
+	m.AddSubMenus(m.homeMenu, menus)
+	m.plugins = append(m.plugins, registeredPlugin{plugin: plugin, menus: menus})
+	m.debug("plugin registered", "plugin", name, "menus", len(menus))
+	return nil
+}
+
+// Unregister will remove the menus the named plugin added, reporting whether it was registered (hooks and key
+// bindings added by its Init stay)
+func (m *MenuTree) Unregister(name string) bool {
+	for i, p := range m.plugins {
+		if p.plugin.Name() != name {
+			continue
+		}
+		for _, menu := range p.menus {
+			m.DeleteSubMenu(m.homeMenu, menu)
+		}
+		m.plugins = append(m.plugins[:i], m.plugins[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// Plugins will return the names of the registered plugins, in the order they were registered
+func (m *MenuTree) Plugins() []string {
+	names := make([]string, 0, len(m.plugins))
+	for _, p := range m.plugins {
+		names = append(names, p.plugin.Name())
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package gomenutree
+
+import (
+	"fmt"
+	"time"
+)
+
+// policyNoticeTTL is how long the notification explaining why an option did not run is shown
+const policyNoticeTTL = 3 * time.Second
+
+// SetCooldown will keep the named option from running again until the cooldown has passed since its last run finished
+// (e.g. to protect against double-triggered deployments); the time left is counted down after its label (0 removes it)
+func (m *Menu) SetCooldown(name string, cooldown time.Duration) {
+	if o, ok := m.options[name]; ok {
+		o.cooldown = cooldown
+	}
+}
+
+// blocked reports whether the option may not run now (see blockReason), showing the reason as a notification
+func (m *MenuTree) blocked(name string, o *option) bool {
+	reason := m.blockReason(name, o)
+	if reason == "" {
+		return false
+	}
+	m.debug("option blocked", "option", name, "reason", reason)
+	m.Notify(reason, LevelWarning, policyNoticeTTL)
+	return true
+}
+
+// blockReason returns why the option may not run now ("" if it may): it is still running (async options), another
+// option is running and Serialize is set, or it is cooling down
+func (m *MenuTree) blockReason(name string, o *option) string {
+	m.runs.mu.Lock()
+	defer m.runs.mu.Unlock()
+	if s, ok := m.runs.async[o]; ok && s.finished.IsZero() {
+		return fmt.Sprintf(m.Strings.AlreadyRunning, name)
+	} else if running := m.runningAsync(); m.Serialize && running != "" {
+		return fmt.Sprintf(m.Strings.Busy, running)
+	} else if left := time.Until(m.runs.cooldowns[o]); left > 0 {
+		return fmt.Sprintf(m.Strings.CoolingDown, name, roundUp(left))
+	}
+	return ""
+}
+
+// runningAsync returns the name of an async option still running ("" if none is), runs.mu must be held
+func (m *MenuTree) runningAsync() string {
+	for _, s := range m.runs.async {
+		if s.finished.IsZero() {
+			return s.name
+		}
+	}
+	return ""
+}
+
+// startCooldown starts the option's cooldown (if it has one) once a run has finished, redrawing the menu every second
+// until it is over so the countdown in its label stays current
+func (m *MenuTree) startCooldown(o *option) {
+	if o.cooldown <= 0 {
+		return
+	}
+	m.runs.mu.Lock()
+	ready := time.Now().Add(o.cooldown)
+	m.runs.cooldowns[o] = ready
+	m.runs.mu.Unlock()
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.refresh()
+			if !time.Now().Before(ready) {
+				return
+			}
+		}
+	}()
+}
+
+// cooldownSuffix returns the time left shown after a cooling down option's label (empty if it may run)
+func (m *MenuTree) cooldownSuffix(o *option) string {
+	m.runs.mu.Lock()
+	ready, ok := m.runs.cooldowns[o]
+	m.runs.mu.Unlock()
+	if left := time.Until(ready); ok && left > 0 {
+		return " [" + fmt.Sprintf(m.Strings.Cooldown, roundUp(left)) + "]"
+	}
+	return ""
+}
+
+// roundUp returns the duration rounded up to the second, so a countdown never shows 0s while still running
+func roundUp(d time.Duration) time.Duration {
+	return (d + time.Second - 1).Truncate(time.Second)
+}
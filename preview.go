@@ -0,0 +1,89 @@
+package gomenutree
+
+import (
+	"strings"
+)
+
+// SetOptionPreview will set a function generating the preview (e.g. details of a server) shown in a pane to the
+// right of the menu while the named option is highlighted
+func (m *Menu) SetOptionPreview(name string, previewFunction func() string) {
+	if o, ok := m.options[name]; ok {
+		o.preview = previewFunction
+	}
+}
+
+// SetPreview will set a function generating the preview shown while this menu is highlighted as a submenu
+func (m *Menu) SetPreview(previewFunction func() string) {
+	m.preview = previewFunction
+}
+
+// hasPreviews reports whether any entry of the current menu has a preview (which turns on the preview pane)
+func (m *MenuTree) hasPreviews() bool {
+	for _, o := range m.currentMenu.options {
+		if o.preview != nil {
+			return true
+		}
+	}
+	for _, sm := range m.subMenuMap[m.currentMenu] {
+		if sm.preview != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// currentPreview returns the preview of the highlighted entry (empty if it has none)
+func (m *MenuTree) currentPreview() string {
+	menu := m.currentMenu
+	state := m.state(menu)
+	if state.selection < len(menu.optionsOrder) {
+		if o, ok := menu.options[menu.optionsOrder[state.selection]]; ok && o.preview != nil {
+			return o.preview()
+		}
+		return ""
+	}
+	if smm := m.subMenuMap[menu]; state.selection-len(menu.optionsOrder) < len(smm) {
+		if sm := smm[state.selection-len(menu.optionsOrder)]; sm.preview != nil {
+			return sm.preview()
+		}
+	}
+	return ""
+}
+
+// addPreview places the preview pane to the right of the frame lines (growing them if the preview is taller),
+// keeping the pane within PreviewWidth and the terminal width if known
+func (m *MenuTree) addPreview(lines []string, menuStyle Style) []string {
+	if !m.hasPreviews() {
+		return lines
+	}
+	left := 0
+	for _, l := range lines {
+		if w := displayWidth(l); w > left {
+			left = w
+		}
+	}
+	paneWidth := m.PreviewWidth
+	if m.width > 0 && m.width-left-10 < paneWidth {
+		paneWidth = m.width - left - 10
+	}
+	if paneWidth < 10 {
+		return lines
+	}
+	preview := strings.Replace(m.currentPreview(), "\r\n", "\n", -1)
+	previewLines := strings.Split(strings.TrimRight(preview, "\n"), "\n")
+	for len(lines) < len(previewLines) {
+		lines = append(lines, "")
+	}
+	for i, l := range lines {
+		l += strings.Repeat(" ", left-displayWidth(l)) + " " + apply(menuStyle.Frame, "|")
+		if i < len(previewLines) {
+			pl := previewLines[i]
+			if displayWidth(pl) > paneWidth {
+				pl = truncate(pl, paneWidth)
+			}
+			l += " " + pl
+		}
+		lines[i] = l
+	}
+	return lines
+}
@@ -0,0 +1,104 @@
+package gomenutree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are drawn in turn while a progress option is running
+var spinnerFrames = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
+
+// Progress is handed to progress option functions so they can report how far along they are
+type Progress struct {
+	mu      sync.Mutex
+	percent float64
+	message string
+}
+
+// SetProgress will update the progress bar (percent from 0 to 100, negative hides the bar) and its message
+func (p *Progress) SetProgress(percent float64, message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent > 100 {
+		percent = 100
+	}
+	p.percent = percent
+	p.message = message
+}
+
+// AddProgressOption will add an option for a long running function: while it runs, an animated spinner, the elapsed
+// time and any progress the function reports are drawn in place, and its output is shown once it finishes (a nil
+// function is added without one, reported by Validate)
+func (m *Menu) AddProgressOption(name string, function func(progress *Progress)) {
+	if function == nil {
+		m.addOption(name, &option{})
+		return
+	}
+	m.addOption(name, &option{
+		function: func() {
+			function(&Progress{percent: -1})
+		},
+		progressFunction: function,
+	})
+}
+
+// runWithProgress runs the progress function with its output captured, animating the progress line until it returns
+func (m *MenuTree) runWithProgress(function func(progress *Progress)) []string {
+	p := new(Progress)
+	p.percent = -1
+	out := m.out
+	if m.hosted {
+		out = io.Discard
+	}
+	start := time.Now()
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			m.drawProgress(out, p, spinnerFrames[frame%len(spinnerFrames)], time.Since(start))
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	var lines []string
+	func() {
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+		lines = m.capture(func() {
+			function(p)
+		})
+	}()
+	m.drawProgress(out, p, '✓', time.Since(start))
+	fmt.Fprintln(out)
+	return lines
+}
+
+// drawProgress redraws the progress line in place
+func (m *MenuTree) drawProgress(out io.Writer, p *Progress, glyph rune, elapsed time.Duration) {
+	p.mu.Lock()
+	percent, message := p.percent, p.message
+	p.mu.Unlock()
+	line := fmt.Sprintf("%c %.1fs", glyph, elapsed.Seconds())
+	if percent >= 0 {
+		filled := int(percent / 5)
+		line += fmt.Sprintf(" [%s%s] %3.0f%%", strings.Repeat("#", filled), strings.Repeat(" ", 20-filled), percent)
+	}
+	if message != "" {
+		line += " " + message
+	}
+	if m.width > 0 && displayWidth(line) > m.width {
+		line = truncate(line, m.width)
+	}
+	fmt.Fprint(out, "\r\033[2K"+line)
+}
@@ -0,0 +1,133 @@
+package gomenutree
+
+import (
+	"strings"
+)
+
+// usage tracks how often and how recently options were run, by option path
+type usage struct {
+	counts map[string]int
+	recent []string //most recent first
+	show   int      //number of entries in the recent menu (0 hides it)
+	menu   *Menu
+	sorted map[*Menu]bool
+}
+
+// ShowRecent will list the most recently run options (up to count, 0 hides the list) in a "Recent" menu near the top
+// of the home menu
+func (m *MenuTree) ShowRecent(count int) {
+	u := m.usage()
+	u.show = count
+	m.updateRecent()
+}
+
+// SortByUsage will keep the options of the menus sorted by how often they were run, most used first (separators
+// stay in place, see SortOptions)
+func (m *MenuTree) SortByUsage(menus ...*Menu) {
+	u := m.usage()
+	for _, menu := range menus {
+		u.sorted[menu] = true
+		m.sortByUsage(menu)
+	}
+}
+
+// Recent will return the paths of the options run, most recent first
+func (m *MenuTree) Recent() []string {
+	return append([]string(nil), m.usage().recent...)
+}
+
+// UsageCount will return how often the option at the "/" separated path has been run
+func (m *MenuTree) UsageCount(path string) int {
+	return m.usage().counts[path]
+}
+
+// usage returns the usage tracking, creating it on first use
+func (m *MenuTree) usage() *usage {
+	if m.usageStats == nil {
+		m.usageStats = &usage{counts: make(map[string]int), menu: NewMenu(m.Strings.Recent, "", nil), sorted: make(map[*Menu]bool)}
+	}
+	return m.usageStats
+}
+
+// trackUsage counts the option run, updating the recent menu and re-sorting its menu if sorted by usage
+func (m *MenuTree) trackUsage(menu *Menu, name string) {
+	u := m.usage()
+	path := name
+	if menu != u.menu && (m.favorites == nil || menu != m.favorites.menu) {
+		path = strings.Join(append(m.menuPath(menu), name), "/")
+	}
+	u.counts[path]++
+	for i, p := range u.recent {
+		if p == path {
+			u.recent = append(u.recent[:i], u.recent[i+1:]...)
+			break
+		}
+	}
+	u.recent = append([]string{path}, u.recent...)
+	if u.sorted[menu] {
+		m.sortByUsage(menu)
+		if i := entryIndex(m, menu, name); i >= 0 {
+			m.state(menu).selection = i
+		}
+	}
+	if u.show > 0 && menu != u.menu {
+		m.updateRecent()
+	}
+}
+
+// sortByUsage sorts the menu's options by their usage count, most used first
+func (m *MenuTree) sortByUsage(menu *Menu) {
+	u := m.usage()
+	prefix := strings.Join(m.menuPath(menu), "/") + "/"
+	menu.SortOptions(func(a, b string) bool {
+		return u.counts[prefix+a] > u.counts[prefix+b]
+	})
+}
+
+// updateRecent rebuilds the recent menu, listing it in the home menu (after the favorites) while it has entries
+func (m *MenuTree) updateRecent() {
+	u := m.usage()
+	paths := u.recent
+	if len(paths) > u.show {
+		paths = paths[:u.show]
+	}
+	position := 0
+	if m.favorites != nil && len(m.favorites.paths) > 0 {
+		position = 1
+	}
+	m.syncPathMenu(u.menu, paths, position)
+}
+
+// copyUsage gives the tree (a session or clone of this one, see configured) usage tracking of its own, starting as a
+// copy of this tree's counts and recent options and listing its own recent menu in its home menu; menus sorted by
+// usage are not re-sorted by the copy, as a session shares them with this tree
+func (m *MenuTree) copyUsage(c *MenuTree) {
+	if m.usageStats == nil {
+		return
+	}
+	u := m.usageStats
+	c.usageStats = &usage{counts: make(map[string]int, len(u.counts)), recent: append([]string(nil), u.recent...),
+		show: u.show, menu: NewMenu(u.menu.name, "", nil), sorted: make(map[*Menu]bool)}
+	for path, count := range u.counts {
+		c.usageStats.counts[path] = count
+	}
+	c.homeMenu.DeleteOption(u.menu.name)
+	if u.show > 0 {
+		c.updateRecent()
+	}
+}
+
+// restoreUsage applies usage counts and recent paths loaded from the state store
+func (m *MenuTree) restoreUsage(counts map[string]int, recent []string) {
+	u := m.usage()
+	for path, count := range counts {
+		u.counts[path] = count
+	}
+	u.recent = append(u.recent, recent...)
+	for menu := range u.sorted {
+		m.sortByUsage(menu)
+	}
+	if u.show > 0 {
+		m.updateRecent()
+	}
+}
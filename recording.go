@@ -0,0 +1,79 @@
+package gomenutree
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type (
+	// Recording holds the key events captured during Display, for saving and replaying sessions
+	Recording struct {
+		Keys []RecordedKey `json:"keys"`
+	}
+
+	// RecordedKey is a single captured key event along with its offset from the start of the recording
+	RecordedKey struct {
+		Key    string        `json:"key"`
+		Offset time.Duration `json:"offset"`
+	}
+)
+
+// StartRecording will begin capturing every key event the menu receives (discarding any previous recording)
+func (m *MenuTree) StartRecording() {
+	m.recording = new(Recording)
+	m.recordStart = time.Now()
+}
+
+// StopRecording will stop capturing key events and return what was recorded (nil if not recording)
+func (m *MenuTree) StopRecording() *Recording {
+	r := m.recording
+	m.recording = nil
+	return r
+}
+
+// record will append the key event (or line of text) to the active recording, if any
+func (m *MenuTree) record(key string) {
+	if m.recording != nil {
+		m.recording.Keys = append(m.recording.Keys, RecordedKey{Key: key, Offset: time.Since(m.recordStart)})
+	}
+}
+
+// LoadRecording will read a recording previously written with Save
+func LoadRecording(r io.Reader) (*Recording, error) {
+	rec := new(Recording)
+	if e := json.NewDecoder(r).Decode(rec); e != nil {
+		return nil, e
+	}
+	return rec, nil
+}
+
+// Save will write the recording as JSON
+func (r *Recording) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Replay will return an input function (for SetInputFunc) that plays back the recorded key events in order,
+// optionally waiting to deliver each key at its original offset; "EXIT" is returned once the recording is exhausted
+func (r *Recording) Replay(realTime bool) func() string {
+	idx := 0
+	var start time.Time
+	return func() string {
+		if idx >= len(r.Keys) {
+			return "EXIT"
+		}
+		if start.IsZero() {
+			start = time.Now()
+		}
+		k := r.Keys[idx]
+		idx++
+		if realTime {
+			if wait := k.Offset - time.Since(start); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		return k.Key
+	}
+}
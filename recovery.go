@@ -0,0 +1,83 @@
+package gomenutree
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// recovery is where the diagnostic dump goes when Display recovers from a panic (see WithRecovery)
+type recovery struct {
+	w    io.Writer
+	path string
+}
+
+// WithRecovery will make Display recover from a panic (in the menu or an option run from it): the terminal is
+// restored (normal mode, cursor shown, bracketed paste off), a diagnostic dump (menu path, selection, last input bytes,
+// terminal size, the panic and its stack) is written to w (os.Stderr if nil) and, if path is not "", to that file for
+// bug reports, and Display returns ExitPanic with the panic as the error; panics in other goroutines (e.g. async
+// options) are not recovered. Returns the tree for chaining, e.g. tree.WithRecovery(nil, "crash.txt").Display()
+func (m *MenuTree) WithRecovery(w io.Writer, path string) *MenuTree {
+	m.recovery = &recovery{w: w, path: path}
+	return m
+}
+
+// crashed restores the terminal after the recovered panic and writes the dump, returning the error Display returns
+func (m *MenuTree) crashed(r interface{}) error {
+	stack := debug.Stack()
+	m.displaying, m.exitReason = false, ExitPanic
+	m.closeTTY()
+	fmt.Fprint(m.out, "\033[?25h\033[?2004l\n")
+	dump := m.crashDump(r, stack)
+	w := m.recovery.w
+	if w == nil {
+		w = os.Stderr
+	}
+	if path := m.recovery.path; path != "" {
+		if e := os.WriteFile(path, []byte(dump), 0o600); e != nil {
+			dump += fmt.Sprintf("writing %s: %v\n", path, e)
+		} else {
+			dump += fmt.Sprintf("written to %s\n", path)
+		}
+	}
+	_, _ = io.WriteString(w, dump)
+	return fmt.Errorf("gomenutree: recovered from panic: %v", r)
+}
+
+// crashDump describes the menu's state when the panic happened
+func (m *MenuTree) crashDump(r interface{}, stack []byte) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "gomenutree: recovered from panic: %v\n", r)
+	fmt.Fprintf(&sb, "time:       %s (%s %s/%s)\n", time.Now().Format(time.RFC3339), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if m.currentMenu != nil {
+		state := m.state(m.currentMenu)
+		fmt.Fprintf(&sb, "menu:       %s\n", strings.Join(m.menuPath(m.currentMenu), " > "))
+		fmt.Fprintf(&sb, "selection:  %d %q\n", state.selection, m.entryName(state.selection))
+		fmt.Fprintf(&sb, "rendered:   %d lines\n", state.lastRenderLines)
+	}
+	fmt.Fprintf(&sb, "last input: %q (% x)\n", m.lastInput, m.lastInput)
+	fmt.Fprintf(&sb, "terminal:   %dx%d (line mode %t, redraw %t)\n", m.width, m.height, m.lineMode, m.Redraw)
+	fmt.Fprintf(&sb, "stack:\n%s", stack)
+	return sb.String()
+}
+
+// entryName returns the name of the option or submenu at the index of the current menu ("" for the navigation rows)
+func (m *MenuTree) entryName(index int) string {
+	if index >= 0 && index < len(m.currentMenu.optionsOrder) {
+		return m.currentMenu.optionsOrder[index]
+	}
+	if smm := m.subMenuMap[m.currentMenu]; index >= len(m.currentMenu.optionsOrder) && index-len(m.currentMenu.optionsOrder) < len(smm) {
+		return smm[index-len(m.currentMenu.optionsOrder)].name
+	}
+	return m.navigationRow(index)
+}
+
+// keyEvent records the bytes of the keystroke read (for the crash dump) and translates them (see parseKey)
+func (m *MenuTree) keyEvent(bb []byte) string {
+	m.lastInput = append(m.lastInput[:0], bb...)
+	return parseKey(bb)
+}
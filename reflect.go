@@ -0,0 +1,86 @@
+package gomenutree
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NewMenuTreeFromStruct will build a menu tree from a struct (or pointer to struct), with name as the home menu:
+// exported methods taking no parameters and returning nothing become options named after the method,
+// fields tagged `menu:"label"` become options (func() fields) or submenus (struct or pointer to struct fields)
+// labeled by the tag (or the field name if the tag is empty); `menu:"-"` and untagged fields are ignored
+func NewMenuTreeFromStruct(name string, v interface{}) (*MenuTree, error) {
+	rv := reflect.ValueOf(v)
+	if reflect.Indirect(rv).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("gomenutree: expected a struct or pointer to struct, got %T", v)
+	}
+	home := NewMenu(name, "", nil)
+	m := NewMenuTree(home)
+	seen := make(map[uintptr]*Menu)
+	if rv.Kind() == reflect.Ptr {
+		seen[rv.Pointer()] = home
+	}
+	if e := m.addStruct(home, rv, seen); e != nil {
+		return nil, e
+	}
+	return m, nil
+}
+
+// addStruct adds the options and submenus described by the struct value to the menu,
+// reusing already built menus for pointers seen before (so pointer cycles become submenu cycles)
+func (m *MenuTree) addStruct(menu *Menu, rv reflect.Value, seen map[uintptr]*Menu) error {
+	for i := 0; i < rv.NumMethod(); i++ {
+		if f, ok := rv.Method(i).Interface().(func()); ok {
+			menu.AddOption(rv.Type().Method(i).Name, f)
+		}
+	}
+	sv := reflect.Indirect(rv)
+	for i := 0; i < sv.NumField(); i++ {
+		sf := sv.Type().Field(i)
+		label, ok := sf.Tag.Lookup("menu")
+		if !ok || label == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return fmt.Errorf("gomenutree: tagged field %s.%s must be exported", sv.Type().Name(), sf.Name)
+		}
+		if label == "" {
+			label = sf.Name
+		}
+		fv := sv.Field(i)
+		switch {
+		case fv.Kind() == reflect.Func:
+			f, ok := fv.Interface().(func())
+			if !ok || f == nil {
+				return fmt.Errorf("gomenutree: field %s.%s must be a non-nil func()", sv.Type().Name(), sf.Name)
+			}
+			menu.AddOption(label, f)
+		case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+			if fv.IsNil() {
+				return fmt.Errorf("gomenutree: field %s.%s is a nil submenu", sv.Type().Name(), sf.Name)
+			}
+			if child, ok := seen[fv.Pointer()]; ok {
+				m.AddSubMenu(menu, child)
+				continue
+			}
+			child := NewMenu(label, "", nil)
+			seen[fv.Pointer()] = child
+			m.AddSubMenu(menu, child)
+			if e := m.addStruct(child, fv, seen); e != nil {
+				return e
+			}
+		case fv.Kind() == reflect.Struct:
+			if fv.CanAddr() {
+				fv = fv.Addr()
+			}
+			child := NewMenu(label, "", nil)
+			m.AddSubMenu(menu, child)
+			if e := m.addStruct(child, fv, seen); e != nil {
+				return e
+			}
+		default:
+			return fmt.Errorf("gomenutree: field %s.%s has unsupported type %s", sv.Type().Name(), sf.Name, fv.Type())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package gomenutree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// region is the rectangle of the terminal the menu is drawn in (see SetRegion)
+type region struct {
+	x, y          int
+	width, height int
+	screen        io.Writer
+}
+
+// SetRegion will draw the menu inside a fixed rectangle of the terminal (columns from x, rows from y, both counted
+// from 0 at the top left) instead of at the cursor, so it can share the screen with output drawn by the application;
+// messages and option output are shown inside the rectangle below the menu (keeping the last lines if it does not
+// fit), and the cursor is put back where it was after each draw. Call it after SetIO, a width or height of 0 goes back
+// to drawing at the cursor
+func (m *MenuTree) SetRegion(x int, y int, width int, height int) {
+	if m.region != nil {
+		m.out = m.region.screen
+		m.region = nil
+	}
+	if width <= 0 || height <= 0 {
+		return
+	}
+	m.region = &region{x: x, y: y, width: width, height: height, screen: m.out}
+	m.hostOut = new(bytes.Buffer)
+	m.out = m.hostOut
+	m.SetSize(width, height)
+}
+
+// drawRegion draws the menu frame and any messages into the region, padding every row to clear what was there
+func (m *MenuTree) drawRegion() {
+	r := m.region
+	lines := strings.Split(strings.TrimPrefix(m.frame(), "\n"), "\n")
+	if out := strings.TrimSpace(m.hostOut.String()); out != "" {
+		lines = append(append(lines, ""), strings.Split(out, "\n")...)
+	}
+	if len(lines) > r.height {
+		lines = lines[len(lines)-r.height:]
+	}
+	var sb strings.Builder
+	sb.WriteString("\0337")
+	for row := 0; row < r.height; row++ {
+		line := ""
+		if row < len(lines) {
+			line = strings.TrimRight(ansiPattern.ReplaceAllStringFunc(lines[row], keepStyle), "\r")
+		}
+		if displayWidth(line) > r.width {
+			line = truncateStyled(line, r.width)
+		}
+		sb.WriteString(fmt.Sprintf("\033[%d;%dH", r.y+row+1, r.x+1) + line + strings.Repeat(" ", r.width-displayWidth(line)))
+	}
+	sb.WriteString("\0338")
+	fmt.Fprint(r.screen, sb.String())
+}
+
+// keepStyle drops the cursor movement and line clearing sequences an option may have printed, keeping styling
+func keepStyle(sequence string) string {
+	if strings.HasSuffix(sequence, "m") {
+		return sequence
+	}
+	return ""
+}
+
+// terminal returns the writer reaching the terminal itself (rather than the region's message area)
+func (m *MenuTree) terminal() io.Writer {
+	if m.region != nil {
+		return m.region.screen
+	}
+	return m.out
+}
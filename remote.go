@@ -0,0 +1,310 @@
+package gomenutree
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// remoteHandler serves the remote-control API of a menu tree
+	remoteHandler struct {
+		m     *MenuTree
+		token string
+	}
+
+	// remoteState is the body of GET /state
+	remoteState struct {
+		Busy        bool           `json:"busy"`
+		Menu        string         `json:"menu,omitempty"`
+		Path        []string       `json:"path,omitempty"`
+		Prompt      string         `json:"prompt,omitempty"`
+		Environment string         `json:"environment,omitempty"`
+		Options     []remoteOption `json:"options,omitempty"`
+		SubMenus    []string       `json:"submenus,omitempty"`
+	}
+
+	// remoteOption is an option listed in the state
+	remoteOption struct {
+		Name     string `json:"name"`
+		Label    string `json:"label"`
+		SubMenu  bool   `json:"submenu,omitempty"`
+		Disabled bool   `json:"disabled,omitempty"`
+		Status   string `json:"status,omitempty"`
+	}
+
+	// remoteRequest is the body of POST /navigate and POST /execute
+	remoteRequest struct {
+		Path   string `json:"path"`
+		Option string `json:"option"`
+	}
+
+	// remoteResult is the body of a successful POST /execute
+	remoteResult struct {
+		Started bool     `json:"started,omitempty"`
+		Output  []string `json:"output,omitempty"`
+		Error   string   `json:"error,omitempty"`
+	}
+
+	// remoteError is an error with the HTTP status it is answered with
+	remoteError struct {
+		status  int
+		message string
+	}
+)
+
+// remoteNoticeTTL is how long the operator is shown what was done remotely
+const remoteNoticeTTL = 5 * time.Second
+
+// errRemoteBusy is answered while the menu is not waiting for input (an option runs, or it is not displayed)
+var errRemoteBusy = &remoteError{http.StatusConflict, "menu is busy"}
+
+// Error implements error
+func (e *remoteError) Error() string {
+	return e.message
+}
+
+// RemoteHandler will return an HTTP handler driving the menu shown by Display, so automation or a dashboard can take
+// the same actions as the operator; every request needs the header "Authorization: Bearer <token>" (an empty token
+// rejects all of them):
+//
+//	GET  /state    the current menu, its path, prompt, options and submenus as JSON ({"busy":true} while not waiting for input)
+//	POST /navigate {"path":"Settings/Network"} switches menu like Navigate ("" for the home menu)
+//	POST /execute  {"path":"Settings","option":"Restart"} runs the option (path "" for the current menu), answering its
+//	               output and error, or {"started":true} for async options
+//
+// Actions are taken only while the menu waits for input (409 otherwise) and are shown to the operator as a
+// notification; options needing the terminal (arguments, passphrases, confirmation phrases, progress) are refused
+// (403), and options run remotely must not read input (commands get no stdin) or call Stop. The output answered is
+// what the option wrote to Writer (or WriterFrom for context options), not its stdout, which stays on the operator's
+// terminal
+func (m *MenuTree) RemoteHandler(token string) http.Handler {
+	return &remoteHandler{m: m, token: token}
+}
+
+// ServeRemote will serve RemoteHandler on the address (e.g. "127.0.0.1:8080") in the background, returning the server
+// so it can be shut down, or the error if the address can not be listened on
+func (m *MenuTree) ServeRemote(addr string, token string) (*http.Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("gomenutree: remote API needs a token")
+	}
+	listener, e := net.Listen("tcp", addr)
+	if e != nil {
+		return nil, fmt.Errorf("gomenutree: serving remote API: %w", e)
+	}
+	server := &http.Server{Handler: m.RemoteHandler(token), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	m.debug("remote API listening", "addr", listener.Addr().String())
+	return server, nil
+}
+
+// ServeHTTP implements http.Handler
+func (h *remoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(auth), []byte(h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		return
+	}
+	var want string
+	switch r.URL.Path {
+	case "/state":
+		want = http.MethodGet
+	case "/navigate", "/execute":
+		want = http.MethodPost
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+		return
+	}
+	if r.Method != want {
+		w.Header().Set("Allow", want)
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+	var req remoteRequest
+	if want == http.MethodPost {
+		if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request: " + e.Error()})
+			return
+		}
+	}
+	var body interface{}
+	var e error
+	switch r.URL.Path {
+	case "/state":
+		body = h.m.remoteState()
+	case "/navigate":
+		e = h.m.remoteNavigate(req.Path)
+		body = map[string]bool{"ok": e == nil}
+	case "/execute":
+		body, e = h.m.remoteExecute(req.Path, req.Option)
+	}
+	var re *remoteError
+	if errors.As(e, &re) {
+		writeJSON(w, re.status, map[string]string{"error": re.message})
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// writeJSON answers with the value as JSON
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// remote takes the action while the event loop waits for input (it is held back from handling the next key until the
+// action is done), or returns errRemoteBusy
+func (m *MenuTree) remote(action func() error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.idle || !m.displaying {
+		return errRemoteBusy
+	}
+	return action()
+}
+
+// remoteState returns the state of the current menu
+func (m *MenuTree) remoteState() remoteState {
+	var state remoteState
+	if e := m.remote(func() error {
+		menu := m.currentMenu
+		state.Menu, state.Path, state.Prompt = menu.name, m.menuPath(menu), m.Prompt()
+		state.Environment = m.Environment()
+		for i, name := range menu.optionsOrder {
+			o := menu.options[name]
+			if o.separator || (o.hidden && !m.revealed) || !m.visible(menu, i) {
+				continue
+			}
+			status := strings.TrimSpace(m.asyncSuffix(o) + m.cooldownSuffix(o))
+			state.Options = append(state.Options, remoteOption{Name: name, Label: o.text(name),
+				SubMenu: o.subMenu != nil, Disabled: o.disabled, Status: status})
+		}
+		for i, sm := range m.subMenuMap[menu] {
+			if m.visible(menu, i+len(menu.optionsOrder)) {
+				state.SubMenus = append(state.SubMenus, sm.name)
+			}
+		}
+		return nil
+	}); e != nil {
+		return remoteState{Busy: true}
+	}
+	return state
+}
+
+// remoteNavigate switches to the menu at the path and redraws
+func (m *MenuTree) remoteNavigate(path string) error {
+	e := m.remote(func() error {
+		menu, parent, e := m.remotePath(path)
+		if e != nil {
+			return e
+		}
+		m.changeMenu(menu, parent)
+		return nil
+	})
+	if e == nil {
+		m.Notify(fmt.Sprintf(m.Strings.RemoteNavigated, m.currentMenu.name), LevelInfo, remoteNoticeTTL)
+	}
+	return e
+}
+
+// remoteExecute runs the option of the menu at the path (the current menu if "") with its output captured
+func (m *MenuTree) remoteExecute(path string, name string) (remoteResult, error) {
+	var result remoteResult
+	e := m.remote(func() error {
+		menu := m.currentMenu
+		if path != "" {
+			var e *remoteError
+			if menu, _, e = m.remotePath(path); e != nil {
+				return e
+			}
+		}
+		o, e := m.detachable(menu, name)
+		if e != nil {
+			return e
+		}
+		m.debug("remote execute", "menu", menu.name, "option", name)
+		result = m.runDetached(menu, name, o)
+		return nil
+	})
+	if e == nil { //the notification redraws the menu once the event loop is idle again (see refresh)
+		m.Notify(fmt.Sprintf(m.Strings.RemoteExecuted, name), LevelInfo, remoteNoticeTTL)
+	}
+	return result, e
+}
+
+// remotePath returns the menu at the path and its parent if every menu along it can be opened without a passphrase
+// (403 if one is protected, 404 if the path does not lead to a visible menu)
+func (m *MenuTree) remotePath(path string) (*Menu, *Menu, *remoteError) {
+	menu, parent, e := m.openPath(path)
+	switch {
+	case errors.Is(e, errProtected):
+		return nil, nil, &remoteError{http.StatusForbidden, e.Error()}
+	case e != nil:
+		return nil, nil, &remoteError{http.StatusNotFound, e.Error()}
+	}
+	return menu, parent, nil
+}
+
+// detachable returns the named option of the menu if it can run without the terminal right now, or why not
+func (m *MenuTree) detachable(menu *Menu, name string) (*option, *remoteError) {
+	o, ok := menu.options[name]
+	index := -1
+	for i, n := range menu.optionsOrder {
+		if n == name {
+			index = i
+		}
+	}
+	switch {
+	case !ok || o.separator || (o.hidden && !m.revealed) || !m.visible(menu, index):
+		return nil, &remoteError{http.StatusNotFound, fmt.Sprintf("no option %q in menu %q", name, menu.name)}
+	case o.disabled:
+		return nil, &remoteError{http.StatusForbidden, fmt.Sprintf("option %q is disabled: %s", name, o.reason)}
+	case o.gate != nil:
+		return nil, &remoteError{http.StatusForbidden, fmt.Sprintf("option %q is protected", name)}
+	case o.subMenu != nil || o.jump != nil:
+		return nil, &remoteError{http.StatusBadRequest, fmt.Sprintf("option %q is not an action, navigate instead", name)}
+	case needsTerminal(o):
+		return nil, &remoteError{http.StatusForbidden, fmt.Sprintf("option %q needs the terminal", name)}
+	}
+	if reason := m.blockReason(name, o); reason != "" {
+		return nil, &remoteError{http.StatusConflict, reason}
+	}
+	return o, nil
+}
+
+// needsTerminal reports whether the option prompts for input or draws progress before or while it runs
+func needsTerminal(o *option) bool {
+	return o.gate != nil || o.args != nil || o.confirmPhrase != "" || o.progressFunction != nil
+}
+
+// runDetached runs the option with what it writes to Writer captured (it runs off the event loop, so stdout is left
+// alone), or starts it in the background if it is async
+func (m *MenuTree) runDetached(menu *Menu, name string, o *option) remoteResult {
+	var result remoteResult
+	m.trackUsage(menu, name)
+	if o.asyncFunction != nil {
+		m.startAsync(menu, name, o)
+		result.Started = true
+		return result
+	}
+	var e error
+	result.Output = m.captureWriter(func() {
+		e = m.audited(menu, name, func() error {
+			return m.invoke(name, o, nil, nil, false) //commands never read the operator's terminal
+		})
+	})
+	m.startCooldown(o)
+	if e != nil {
+		result.Error = e.Error()
+	}
+	return result
+}
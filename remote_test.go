@@ -0,0 +1,161 @@
+package gomenutree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// display shows the menu tree in the background until the returned function is called (it waits for Display to
+// return), taking no keys until then
+func display(t *testing.T, m *MenuTree) (stop func()) {
+	events := make(chan string)
+	m.SetIO(strings.NewReader(""), &syncWriter{w: io.Discard})
+	m.SetSize(80, 24)
+	m.SetInputFunc(func() string {
+		return <-events
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, e := m.Display(); e != nil {
+			t.Error(e)
+		}
+	}()
+	events <- " " //dismisses the welcome screen
+	return func() {
+		events <- "EXIT"
+		<-done
+	}
+}
+
+// remoteCall sends the request to the handler, decoding its JSON answer into body
+func remoteCall(t *testing.T, h http.Handler, method string, path string, request string, body interface{}) int {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(request))
+	r.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if e := json.NewDecoder(w.Body).Decode(body); e != nil {
+		t.Fatalf("%s %s: decoding %q: %v", method, path, w.Body.String(), e)
+	}
+	return w.Code
+}
+
+// waitIdle waits until the remote API answers the state of a menu waiting for input
+func waitIdle(t *testing.T, h http.Handler) remoteState {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var state remoteState
+		if remoteCall(t, h, http.MethodGet, "/state", "", &state) == http.StatusOK && !state.Busy {
+			return state
+		}
+	}
+	t.Fatal("the menu never waited for input")
+	return remoteState{}
+}
+
+// TestRemoteHandler drives a displayed menu through the remote API: state, navigation and execution
+func TestRemoteHandler(t *testing.T) {
+	home := NewMenu("Home", "", nil)
+	var m *MenuTree
+	home.AddOption("Status", func() { fmt.Fprintln(m.Writer(), "all good") })
+	home.AddArgOption("Greet", func(name string) {}, Arg{Name: "name"})
+	settings := NewMenu("Settings", "", nil)
+	settings.AddOption("Network", func() {})
+	m = NewMenuTree(home)
+	m.AddSubMenu(home, settings)
+	defer display(t, m)()
+	h := m.RemoteHandler("secret")
+
+	state := waitIdle(t, h)
+	if state.Menu != "Home" || len(state.Options) != 2 || state.Options[0].Name != "Status" {
+		t.Errorf("state = %+v, want the home menu with its options", state)
+	}
+	var result remoteResult
+	if code := remoteCall(t, h, http.MethodPost, "/execute", `{"option":"Status"}`, &result); code != http.StatusOK {
+		t.Fatalf("execute answered %d", code)
+	}
+	if strings.Join(result.Output, "\n") != "all good" {
+		t.Errorf("output = %q, want what the option wrote", result.Output)
+	}
+	var refused map[string]string
+	if code := remoteCall(t, h, http.MethodPost, "/execute", `{"option":"Greet"}`, &refused); code != http.StatusForbidden {
+		t.Errorf("executing an option prompting for arguments answered %d, want %d", code, http.StatusForbidden)
+	}
+	var ok map[string]bool
+	if code := remoteCall(t, h, http.MethodPost, "/navigate", `{"path":"Settings"}`, &ok); code != http.StatusOK {
+		t.Fatalf("navigate answered %d", code)
+	}
+	if state = waitIdle(t, h); state.Menu != "Settings" {
+		t.Errorf("menu after navigating = %q, want Settings", state.Menu)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/state", nil)
+	r.Header.Set("Authorization", "Bearer wrong")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("a wrong token answered %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+// tokenPattern finds the form token of a rendered web page
+var tokenPattern = regexp.MustCompile(`name="token" value="([0-9a-f]+)"`)
+
+// TestWebHandler renders a menu as a page and runs an option posted from its form, only once per token
+func TestWebHandler(t *testing.T) {
+	home := NewMenu("Home", "", nil)
+	var m *MenuTree
+	home.AddOption("Status", func() { fmt.Fprintln(m.Writer(), "all good") })
+	m = NewMenuTree(home)
+	h := m.WebHandler()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Status") {
+		t.Fatalf("GET answered %d: %s", w.Code, w.Body.String())
+	}
+	token := tokenPattern.FindStringSubmatch(w.Body.String())
+	if token == nil {
+		t.Fatalf("no form token in %s", w.Body.String())
+	}
+	post := func() *httptest.ResponseRecorder {
+		form := url.Values{"token": {token[1]}, "option": {"Status"}}
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	if w = post(); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "all good") {
+		t.Errorf("POST answered %d without the option's output: %s", w.Code, w.Body.String())
+	}
+	if w = post(); w.Code != http.StatusForbidden {
+		t.Errorf("posting a used token answered %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("all good")) {
+		t.Error("the option ran again with a used token")
+	}
+}
+
+// TestWebHandlerTokenCap keeps at most webMaxTokens form tokens, however many pages are rendered
+func TestWebHandlerTokenCap(t *testing.T) {
+	home := NewMenu("Home", "", nil)
+	home.AddOption("Status", func() {})
+	h := NewMenuTree(home).WebHandler().(*webHandler)
+	for i := 0; i < webMaxTokens+10; i++ {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if len(h.tokens) != webMaxTokens {
+		t.Errorf("%d tokens kept, want %d", len(h.tokens), webMaxTokens)
+	}
+}
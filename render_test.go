@@ -0,0 +1,105 @@
+package gomenutree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// benchmarkTree returns a menu tree whose home menu has the given number of options, drawing to io.Discard
+func benchmarkTree(options int) *MenuTree {
+	home := NewMenu("Home", "Choose an option", nil)
+	for i := 0; i < options; i++ {
+		home.AddOption(fmt.Sprintf("Option %d", i), func() {})
+	}
+	m := NewMenuTree(home)
+	m.SetIO(nil, io.Discard)
+	m.SetSize(120, 40)
+	m.initSelection()
+	return m
+}
+
+// BenchmarkRender measures redrawing a large menu as the selection moves (what every keystroke does)
+func BenchmarkRender(b *testing.B) {
+	m := benchmarkTree(500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.moveSelection(1)
+		m.render()
+	}
+}
+
+// BenchmarkFrame measures building the frame of a large menu without writing it
+func BenchmarkFrame(b *testing.B) {
+	m := benchmarkTree(500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.frame()
+	}
+}
+
+// snapshotTree returns a small menu tree (two options and a submenu) drawing to io.Discard
+func snapshotTree() *MenuTree {
+	home := NewMenu("Home", "Pick one", nil)
+	home.AddOption("Status", func() {})
+	home.AddOption("Restart", func() {})
+	m := NewMenuTree(home)
+	m.AddSubMenu(home, NewMenu("Settings", "", nil))
+	m.SetIO(nil, io.Discard)
+	m.SetSize(80, 24)
+	m.initSelection()
+	return m
+}
+
+// plainFrame returns the frame RenderString draws, without its styling escape sequences
+func plainFrame(m *MenuTree) string {
+	return ansiPattern.ReplaceAllString(m.RenderString(), "")
+}
+
+// TestRenderStringSnapshots compares the frames drawn for a menu against snapshots of its layout
+func TestRenderStringSnapshots(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *MenuTree)
+		want  string
+	}{
+		{"home", func(m *MenuTree) {},
+			"\n****************\n  Menu: Home\n   Pick one\n  Options:\n  >Status\n   Restart\n  SubMenus:\n   Settings\n" +
+				"  \n**Exit**********"},
+		{"selection moved", func(m *MenuTree) { m.moveSelection(1) },
+			"\n****************\n  Menu: Home\n   Pick one\n  Options:\n   Status\n  >Restart\n  SubMenus:\n   Settings\n" +
+				"  \n**Exit**********"},
+		{"border", func(m *MenuTree) {
+			b := BorderASCII
+			m.Border = &b
+		},
+			"\n+------------+\n| Menu: Home |\n|  Pick one  |\n| Options:   |\n| >Status    |\n|  Restart   |\n| SubMenus:  |\n" +
+				"|  Settings  |\n|            |\n| Exit       |\n+------------+"},
+		{"exit label", func(m *MenuTree) { m.ExitLabel = "Quit" },
+			"\n****************\n  Menu: Home\n   Pick one\n  Options:\n  >Status\n   Restart\n  SubMenus:\n   Settings\n" +
+				"  \n**Quit (x)******"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := snapshotTree()
+			tt.setup(m)
+			if got := plainFrame(m); got != tt.want {
+				t.Errorf("frame:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRenderStringWritesNothing checks that rendering to a string leaves the output untouched
+func TestRenderStringWritesNothing(t *testing.T) {
+	m := snapshotTree()
+	var out bytes.Buffer
+	m.SetIO(nil, &out)
+	_ = m.RenderString()
+	if out.Len() != 0 {
+		t.Errorf("RenderString wrote %q", out.String())
+	}
+}
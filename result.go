@@ -0,0 +1,79 @@
+package gomenutree
+
+// ExitReason describes how a Display session ended
+type ExitReason int
+
+const (
+	ExitUser      ExitReason = iota // the user chose exit
+	ExitInterrupt                   // the user pressed Ctrl+C
+	ExitError                       // reading the terminal failed (Display also returns the error)
+	ExitStopped                     // the application called Stop
+	ExitIdle                        // no key was pressed within the idle timeout (see SetIdleTimeout)
+	ExitResult                      // an option called ExitWith (see Result)
+	ExitPanic                       // a panic was recovered (see WithRecovery, Display also returns it as the error)
+)
+
+// String will return a readable name for the reason
+func (r ExitReason) String() string {
+	switch r {
+	case ExitUser:
+		return "exit"
+	case ExitInterrupt:
+		return "interrupt"
+	case ExitError:
+		return "error"
+	case ExitStopped:
+		return "stopped"
+	case ExitIdle:
+		return "idle"
+	case ExitResult:
+		return "result"
+	case ExitPanic:
+		return "panic"
+	default:
+		return "unknown"
+	}
+}
+
+// Stop will end Display with ExitStopped once the current keystroke or option has been handled
+// (safe to call from option functions and other goroutines)
+func (m *MenuTree) Stop() {
+	m.setStopped(true)
+}
+
+// ExitWith will end Display with ExitResult as soon as the calling option returns (skipping the continue prompt),
+// keeping the result for the program to act on (see Result), e.g. to use the menu as an interactive picker
+func (m *MenuTree) ExitWith(result interface{}) {
+	m.result = result
+	m.end(ExitResult)
+}
+
+// Result will return the value passed to ExitWith in the last session (nil if it ended otherwise)
+func (m *MenuTree) Result() interface{} {
+	if m.exitReason != ExitResult {
+		return nil
+	}
+	return m.result
+}
+
+// end will finish the display loop for the given reason (exit hooks only run when the user chose exit)
+func (m *MenuTree) end(reason ExitReason) {
+	m.debug("display ended", "reason", reason.String())
+	m.exitReason = reason
+	m.displaying = false
+	m.saveState()
+}
+
+// setStopped records whether Stop was requested
+func (m *MenuTree) setStopped(stopped bool) {
+	m.mu.Lock()
+	m.stopped = stopped
+	m.mu.Unlock()
+}
+
+// isStopped reports whether Stop was requested
+func (m *MenuTree) isStopped() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stopped
+}
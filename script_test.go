@@ -0,0 +1,174 @@
+package gomenutree
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedTree returns a menu tree reading the scripted key events (after the key dismissing the welcome screen) and
+// writing to out
+func scriptedTree(home *Menu, out *bytes.Buffer, keys ...string) *MenuTree {
+	m := NewMenuTree(home)
+	m.SetIO(strings.NewReader(""), out)
+	m.SetSize(80, 24)
+	m.SetInputFunc(ScriptedInput(append([]string{" "}, keys...)...))
+	return m
+}
+
+// TestScriptedNavigation runs the option chosen with the arrow keys, and one in a submenu, then exits
+func TestScriptedNavigation(t *testing.T) {
+	var ran []string
+	home := NewMenu("Home", "", nil)
+	home.AddOption("Status", func() { ran = append(ran, "Status") })
+	home.AddOption("Restart", func() { ran = append(ran, "Restart") })
+	settings := NewMenu("Settings", "", nil)
+	settings.AddOption("Network", func() { ran = append(ran, "Network") })
+	var out bytes.Buffer
+	m := scriptedTree(home, &out, "DOWN", "ENTER", " ", "DOWN", "ENTER", "ENTER", " ", "BACK", "EXIT")
+	m.AddSubMenu(home, settings)
+	reason, e := m.Display()
+	if e != nil || reason != ExitUser {
+		t.Fatalf("Display() = %v, %v, want %v", reason, e, ExitUser)
+	}
+	if want := []string{"Restart", "Network"}; strings.Join(ran, ",") != strings.Join(want, ",") {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+	for _, want := range []string{"Menu: Home", "Menu: Settings", "Executing Restart"} {
+		if !strings.Contains(StripANSI(out.String()), want) {
+			t.Errorf("output is missing %q", want)
+		}
+	}
+}
+
+// TestScriptedGate runs a protected option only once its passphrase is typed
+func TestScriptedGate(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		want       bool
+	}{
+		{"right passphrase", "open sesame", true},
+		{"wrong passphrase", "guess", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			home := NewMenu("Home", "", nil)
+			home.AddOption("Wipe", func() { ran = true })
+			home.ProtectOption("Wipe", Gate{Check: Passphrase("open sesame")})
+			var out bytes.Buffer
+			m := scriptedTree(home, &out, "ENTER", tt.passphrase, " ", "EXIT")
+			if _, e := m.Display(); e != nil {
+				t.Fatal(e)
+			}
+			if ran != tt.want {
+				t.Errorf("ran = %v, want %v", ran, tt.want)
+			}
+			if strings.Contains(out.String(), tt.passphrase) {
+				t.Errorf("the passphrase was echoed: %q", out.String())
+			}
+		})
+	}
+}
+
+// TestScriptedArgs prompts for an option's typed parameters, asking again for a value that does not convert
+func TestScriptedArgs(t *testing.T) {
+	var gotName string
+	var gotCount int
+	home := NewMenu("Home", "", nil)
+	e := home.AddArgOption("Greet", func(name string, count int) {
+		gotName, gotCount = name, count
+	}, Arg{Name: "name"}, Arg{Name: "count", Default: "1"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	var out bytes.Buffer
+	m := scriptedTree(home, &out, "ENTER", "bob", "many", "3", " ", "EXIT")
+	if _, e := m.Display(); e != nil {
+		t.Fatal(e)
+	}
+	if gotName != "bob" || gotCount != 3 {
+		t.Errorf("Greet(%q, %d), want Greet(\"bob\", 3)", gotName, gotCount)
+	}
+}
+
+// TestScriptedTimeout reports an option running past its timeout, with its context cancelled
+func TestScriptedTimeout(t *testing.T) {
+	cancelled := make(chan error, 1)
+	home := NewMenu("Home", "", nil)
+	home.AddContextOption("Slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	})
+	home.SetTimeout("Slow", 20*time.Millisecond)
+	var out bytes.Buffer
+	m := scriptedTree(home, &out, "ENTER", " ", "EXIT")
+	if _, e := m.Display(); e != nil {
+		t.Fatal(e)
+	}
+	if e := <-cancelled; !errors.Is(e, context.DeadlineExceeded) {
+		t.Errorf("context error = %v, want %v", e, context.DeadlineExceeded)
+	}
+	if want := fmt.Sprintf(m.Strings.TimedOut, "Slow", 20*time.Millisecond); !strings.Contains(out.String(), want) {
+		t.Errorf("output is missing %q", want)
+	}
+}
+
+// TestScriptedExitWith ends the menu with the result an option gives
+func TestScriptedExitWith(t *testing.T) {
+	home := NewMenu("Home", "", nil)
+	var m *MenuTree
+	home.AddOption("Pick", func() { m.ExitWith(42) })
+	var out bytes.Buffer
+	m = scriptedTree(home, &out, "ENTER")
+	reason, e := m.Display()
+	if e != nil || reason != ExitResult {
+		t.Fatalf("Display() = %v, %v, want %v", reason, e, ExitResult)
+	}
+	if got := m.Result(); got != 42 {
+		t.Errorf("Result() = %v, want 42", got)
+	}
+}
+
+// TestScriptedSessions displays sessions of one tree at once, each option printing only to the session choosing it
+func TestScriptedSessions(t *testing.T) {
+	home := NewMenu("Home", "", nil)
+	home.AddContextOption("Whoami", func(ctx context.Context) error {
+		fmt.Fprintln(WriterFrom(ctx), "printed here")
+		return nil
+	})
+	home.AddOption("Other", func() {})
+	m := NewMenuTree(home)
+	m.Pager = true
+	keys := [][]string{{" ", "ENTER", "q", "EXIT"}, {" ", "DOWN", "ENTER", "q", "EXIT"}}
+	outs := make([]*bytes.Buffer, len(keys))
+	done := make(chan error, len(keys))
+	for i := range keys {
+		s := m.NewSession()
+		outs[i] = new(bytes.Buffer)
+		s.SetIO(strings.NewReader(""), outs[i])
+		s.SetSize(80, 24)
+		s.SetInputFunc(ScriptedInput(keys[i]...))
+		go func() {
+			_, e := s.Display()
+			done <- e
+		}()
+	}
+	for range keys {
+		if e := <-done; e != nil {
+			t.Fatal(e)
+		}
+	}
+	if !strings.Contains(outs[0].String(), "printed here") {
+		t.Error("the session choosing the option is missing its output")
+	}
+	if strings.Contains(outs[1].String(), "printed here") {
+		t.Error("the output of an option reached another session")
+	}
+}
@@ -0,0 +1,142 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetOptionDescription will set a description of the named option, matched by the tree search (see EnableSearch)
+func (m *Menu) SetOptionDescription(name string, description string) {
+	if o, ok := m.options[name]; ok {
+		o.description = description
+	}
+}
+
+// EnableSearch will let the user press key to search option names, descriptions and menu names across the whole
+// tree; matches are listed with their path, and choosing one goes to its menu with the entry highlighted (or runs it,
+// if SearchExecutes is set)
+func (m *MenuTree) EnableSearch(key string) {
+	m.searchKey = strings.ToUpper(key)
+}
+
+// search asks for the text to find and shows the matches as a menu
+func (m *MenuTree) search() {
+	fmt.Fprintln(m.out)
+	query := strings.TrimSpace(m.ReadLine(m.Strings.Search))
+	m.state(m.currentMenu).lastRenderLines += 2
+	if query == "" {
+		m.render()
+		return
+	}
+	if m.searchMenu != nil {
+		delete(m.states, m.searchMenu)
+	}
+	results := NewMenu(fmt.Sprintf(m.Strings.SearchResults, query), "", nil)
+	m.searchMenu = results
+	for _, r := range m.find(strings.ToLower(query)) {
+		r := r
+		label := strings.Join(r.path, " > ")
+		results.addOption(label, &option{function: func() {}, jump: func() {
+			m.jumpTo(r)
+		}})
+	}
+	if len(results.optionsOrder) == 0 {
+		fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.NoMatches, query))
+		fmt.Fprintln(m.out, m.continuePrompt())
+		m.state(m.currentMenu).lastRenderLines += 2
+		m.getInput()
+		m.render()
+		return
+	}
+	m.ChangeMenu(results)
+}
+
+// searchResult is an entry found by the search: the path of menu names leading to it (and its name, unless it is a
+// menu itself)
+type searchResult struct {
+	menu  *Menu
+	index int //entry index in the menu, -1 for the menu itself
+	path  []string
+}
+
+// find returns the visible entries and menus matching the (lower case) query, breadth first from the home menu;
+// protected menus are matched but not searched inside, so their gate is still asked for
+func (m *MenuTree) find(query string) []searchResult {
+	var results []searchResult
+	seen := map[*Menu]bool{m.homeMenu: true}
+	if m.favorites != nil {
+		seen[m.favorites.menu] = true
+	}
+	if m.usageStats != nil {
+		seen[m.usageStats.menu] = true
+	}
+	queue := []*Menu{m.homeMenu}
+	for len(queue) > 0 {
+		menu := queue[0]
+		queue = queue[1:]
+		path := m.menuPath(menu)
+		var subMenus []*Menu
+		for i, name := range menu.optionsOrder {
+			o := menu.options[name]
+			if o.separator || (o.hidden && !m.revealed) || !m.visible(menu, i) {
+				continue
+			}
+			if o.subMenu != nil {
+				subMenus = append(subMenus, o.subMenu)
+				continue
+			}
+			if strings.Contains(strings.ToLower(o.text(name)), query) ||
+				strings.Contains(strings.ToLower(o.description), query) {
+				results = append(results, searchResult{menu: menu, index: i, path: append(path[:len(path):len(path)], name)})
+			}
+		}
+		for i, sm := range m.subMenuMap[menu] {
+			if m.visible(menu, len(menu.optionsOrder)+i) {
+				subMenus = append(subMenus, sm)
+			}
+		}
+		for _, sm := range subMenus {
+			if seen[sm] {
+				continue
+			}
+			seen[sm] = true
+			if strings.Contains(strings.ToLower(sm.name), query) {
+				results = append(results, searchResult{menu: sm, index: -1, path: append(path[:len(path):len(path)], sm.name)})
+			}
+			if sm.gate == nil {
+				queue = append(queue, sm)
+			}
+		}
+	}
+	return results
+}
+
+// jumpTo goes to the result's menu (back leading to its parent), highlighting (or running, if SearchExecutes is set)
+// the entry
+func (m *MenuTree) jumpTo(r searchResult) {
+	menuPath := r.path
+	if r.index >= 0 {
+		menuPath = r.path[:len(r.path)-1]
+	}
+	menu, parent, e := m.resolvePath(strings.Join(menuPath, "/"))
+	if e != nil {
+		fmt.Fprintln(m.out, m.Strings.MenuNotFound)
+		return
+	}
+	if r.index < 0 {
+		if menu.gate != nil && !m.authorize(menu.name, menu.gate) {
+			return
+		}
+		m.changeMenu(menu, parent)
+		return
+	}
+	m.state(menu).selection = r.index
+	m.state(menu).visited = true
+	sticky := m.StickySelection
+	m.StickySelection = true
+	m.changeMenu(menu, parent)
+	m.StickySelection = sticky
+	if m.SearchExecutes {
+		m.execute(r.index)
+	}
+}
@@ -0,0 +1,88 @@
+package gomenutree
+
+// SetDefaultSelection will set the index of the entry (options first, then submenus) selected every time the menu is
+// entered (or only the first time if MenuTree.StickySelection is set)
+func (m *Menu) SetDefaultSelection(index int) {
+	m.defaultIndex = index
+	m.defaultName = ""
+}
+
+// SetDefaultSelectionName will set the option or submenu, by name, selected every time the menu is entered
+// (or only the first time if MenuTree.StickySelection is set)
+func (m *Menu) SetDefaultSelectionName(name string) {
+	m.defaultName = name
+}
+
+// Selection will return the index of the entry under the selection cursor in the current menu
+func (m *MenuTree) Selection() int {
+	return m.state(m.currentMenu).selection
+}
+
+// initSelection will place the selection cursor on entering the current menu,
+// keeping the previous position if sticky, and making sure it rests on a selectable entry
+func (m *MenuTree) initSelection() {
+	menu := m.currentMenu
+	state := m.state(menu)
+	if !m.StickySelection || !state.visited {
+		state.selection = m.defaultSelection(menu)
+	}
+	state.visited = true
+	if state.selection < 0 || state.selection >= m.entryCount() {
+		state.selection = 0
+	}
+	if !m.selectable(state.selection) {
+		m.moveSelection(1)
+	}
+}
+
+// defaultSelection resolves the menu's default selection (by name if set, otherwise by index)
+func (m *MenuTree) defaultSelection(menu *Menu) int {
+	if menu.defaultName == "" {
+		return menu.defaultIndex
+	}
+	if index := entryIndex(m, menu, menu.defaultName); index >= 0 {
+		return index
+	}
+	return menu.defaultIndex
+}
+
+// entryIndex returns the index of the named option or submenu in the menu (options first), -1 if there is none
+func entryIndex(m *MenuTree, menu *Menu, name string) int {
+	for i, n := range menu.optionsOrder {
+		if n == name {
+			return i
+		}
+	}
+	for i, sm := range m.subMenuMap[menu] {
+		if sm.name == name {
+			return len(menu.optionsOrder) + i
+		}
+	}
+	return -1
+}
+
+// selectEdge moves the selection cursor to the first (or last) selectable entry
+func (m *MenuTree) selectEdge(first bool) {
+	state := m.state(m.currentMenu)
+	if first {
+		state.selection = m.entryCount() - 1
+		m.moveSelection(1)
+	} else {
+		state.selection = 0
+		m.moveSelection(-1)
+	}
+}
+
+// movePage moves the selection cursor a page of entries up (-1) or down (1), stopping at the first or last entry
+func (m *MenuTree) movePage(direction int) {
+	page := 10
+	if m.height > 8 {
+		page = m.height / 2
+	}
+	state := m.state(m.currentMenu)
+	for i, next := 0, state.selection+direction; i < page && next >= 0 && next < m.entryCount(); i, next = i+1, next+direction {
+		if m.selectable(next) {
+			state.selection = next
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package gomenutree
+
+import (
+	"fmt"
+)
+
+// AddSeparator will add a non-selectable divider (with an optional label, e.g. a group heading) after the current
+// options; separators are skipped by the selection cursor and never get a hotkey
+func (m *Menu) AddSeparator(label string) {
+	m.separators++
+	if label == "" {
+		label = "----------"
+	} else {
+		label = fmt.Sprintf("-- %s --", label)
+	}
+	m.addOption(fmt.Sprintf("\x00separator%d", m.separators), &option{function: func() {}, separator: true, label: label})
+}
@@ -0,0 +1,63 @@
+package gomenutree
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+const (
+	// serialWidth and serialHeight cap the terminal size used for the frame on a serial console
+	serialWidth  = 80
+	serialHeight = 24
+)
+
+// serialWriter drops escape sequences (colors, cursor movement, terminal modes) and pauses after each line, for slow
+// serial links (see SerialConsole)
+type serialWriter struct {
+	w     io.Writer
+	delay time.Duration
+}
+
+// Write implements io.Writer, reporting the length of the unfiltered input
+func (s serialWriter) Write(p []byte) (int, error) {
+	text := ansiPattern.ReplaceAll(p, nil)
+	for len(text) > 0 {
+		line := text
+		if i := bytes.IndexByte(text, '\n'); i >= 0 && s.delay > 0 {
+			line = text[:i+1]
+		}
+		if _, e := s.w.Write(line); e != nil {
+			return 0, e
+		}
+		text = text[len(line):]
+		if line[len(line)-1] == '\n' && s.delay > 0 {
+			time.Sleep(s.delay)
+		}
+	}
+	return len(p), nil
+}
+
+// serial switches the output to the serial console profile while the menu is displayed, returning the function that
+// switches it back
+func (m *MenuTree) serial() func() {
+	out, redraw := m.out, m.Redraw
+	m.out, m.Redraw = serialWriter{w: out, delay: m.LineDelay}, false
+	m.debug("serial console", "line_delay", m.LineDelay.String())
+	return func() {
+		m.out, m.Redraw = out, redraw
+	}
+}
+
+// capSize keeps the terminal size within 80x24 on a serial console, assuming that size when it is unknown
+func (m *MenuTree) capSize() {
+	if !m.SerialConsole {
+		return
+	}
+	if m.width <= 0 || m.width > serialWidth {
+		m.width = serialWidth
+	}
+	if m.height <= 0 || m.height > serialHeight {
+		m.height = serialHeight
+	}
+}
@@ -0,0 +1,166 @@
+package gomenutree
+
+import "strings"
+
+type (
+	// Session is one viewer of a menu tree (e.g. one SSH connection), created with MenuTree.NewSession: it shares the
+	// tree's options (and whether they are running or cooling down), but has its own copy of the menus and configuration, cursor, menu history, input and output
+	// (option output included) and render cache, so many sessions can display the same tree at once
+	Session struct {
+		*MenuTree
+	}
+
+	// menuState holds a session's view of one menu: the selection cursor, hotkeys and what was last drawn
+	menuState struct {
+		selection       int
+		hotKeys         map[string]int
+		visited         bool
+		columnRows      int
+		cellIndexes     []int
+		lineEntries     []int
+		lastRenderLines int
+		longestLine     int
+		promptText      string   //prompt the cached prompt lines were built from
+		prompt          []string //prompt lines as drawn
+	}
+)
+
+// NewSession will return a new session of the menu tree, starting in the home menu and writing to stdout until
+// SetIO is called; configuration is copied, so a session may change it (e.g. SetVisibilityFunc for the user's roles)
+// without affecting others, favorites and usage (the recent menu) start as a copy and are then the session's own, and
+// each menu is copied too so paging and loading one session's menus never changes another's: options stay shared
+// (an option running or cooling down in one session is for all, see AddAsyncOption and SetCooldown), and a menu's copy is refreshed from the
+// original after its loader runs, so loaders should keep changing the menu they were written for
+func (m *MenuTree) NewSession() *Session {
+	copies := make(map[*Menu]*Menu)
+	s := m.configured(sessionCopy(m.homeMenu, copies))
+	s.session, s.copies = true, copies
+	s.runs = m.runs //options are shared, so is whether they are running or cooling down
+	for parent, children := range m.subMenuMap {
+		parent = sessionCopy(parent, copies)
+		for _, child := range children {
+			s.subMenuMap[parent] = append(s.subMenuMap[parent], sessionCopy(child, copies))
+		}
+	}
+	return &Session{MenuTree: s}
+}
+
+// sessionCopy returns the session's copy of the menu, copying it (and the submenus its options open) on first use
+func sessionCopy(menu *Menu, copies map[*Menu]*Menu) *Menu {
+	if c, ok := copies[menu]; ok {
+		return c
+	}
+	var c *Menu
+	if menu.sessionCopy != nil {
+		c = menu.sessionCopy()
+	} else {
+		c = menu.Clone(false)
+		c.source = menu
+	}
+	copies[menu] = c
+	copySubMenus(c, copies)
+	return c
+}
+
+// copySubMenus points the options of a session's menu opening submenus at the session's copies of them
+func copySubMenus(menu *Menu, copies map[*Menu]*Menu) {
+	for name, o := range menu.options {
+		if o.subMenu != nil {
+			oc := *o
+			oc.subMenu = sessionCopy(o.subMenu, copies)
+			menu.options[name] = &oc
+		}
+	}
+}
+
+// refreshCopy brings a session's copy of a menu up to date with the original after its loader ran, as loaders change
+// the original (e.g. adding an option per item fetched)
+func (m *MenuTree) refreshCopy(menu *Menu) {
+	if menu.source == nil || m.copies == nil {
+		return
+	}
+	fresh := menu.source.Clone(false)
+	menu.options, menu.optionsOrder = fresh.options, fresh.optionsOrder
+	menu.prompt, menu.promptFunction = fresh.prompt, fresh.promptFunction
+	copySubMenus(menu, m.copies)
+}
+
+// configured returns a new menu tree for the home menu with a copy of this tree's configuration
+func (m *MenuTree) configured(homeMenu *Menu) *MenuTree {
+	s := NewMenuTree(homeMenu)
+	s.debugFunc = m.debugFunc
+	s.idleTimeout, s.idleAction = m.idleTimeout, m.idleAction
+	s.status, s.statusFunc = m.status, m.statusFunc
+	s.revealKeys = append([]string(nil), m.revealKeys...)
+	s.exitHooks = append([]func() error(nil), m.exitHooks...)
+	s.visibleFunc = m.visibleFunc
+	s.Redraw, s.Pager, s.Theme = m.Redraw, m.Pager, m.Theme
+	s.Strings = m.Strings
+	s.SkipDisabled, s.StickySelection = m.SkipDisabled, m.StickySelection
+	s.ExitLabel, s.HideExit, s.ConfirmExit = m.ExitLabel, m.HideExit, m.ConfirmExit
+	s.PreviewWidth, s.Columns = m.PreviewWidth, m.Columns
+	s.Border, s.Padding, s.TitleInBorder = m.Border, m.Padding, m.TitleInBorder
+	s.Overflow, s.LineMode = m.Overflow, m.LineMode
+	s.Audit, s.Metrics = m.Audit, m.Metrics
+	s.CopyKey, s.CopyFunc = m.CopyKey, m.CopyFunc
+	s.SubMenuMarker, s.NavigationRows = m.SubMenuMarker, m.NavigationRows
+	s.Pause, s.AutoReturn = m.Pause, m.AutoReturn
+	s.BeforeExecuteBanner, s.AfterExecuteBanner = m.BeforeExecuteBanner, m.AfterExecuteBanner
+	m.copyFavorites(s)
+	m.copyUsage(s)
+	s.searchKey, s.SearchExecutes = m.searchKey, m.SearchExecutes
+	s.RenderInterval = m.RenderInterval
+	s.OutputLines, s.Serialize = m.OutputLines, m.Serialize
+	s.IndentDepth, s.TerminalTitle = m.IndentDepth, m.TerminalTitle
+	s.InvalidKey, s.FlashStyle, s.FlashDuration = m.InvalidKey, m.FlashStyle, m.FlashDuration
+	s.SerialConsole, s.LineDelay = m.SerialConsole, m.LineDelay
+	s.ShowLastAction, s.UndoKey = m.ShowLastAction, m.UndoKey
+	s.Accessible = m.Accessible
+	s.recovery = m.recovery
+	s.plugins = append([]registeredPlugin(nil), m.plugins...)
+	for name, theme := range m.environments {
+		s.AddEnvironment(name, theme)
+	}
+	s.environment = m.Environment()
+	for key, function := range m.keyBindings {
+		s.BindKey(key, function)
+	}
+	return s
+}
+
+// state returns the session's view of the menu, creating it on first use
+func (m *MenuTree) state(menu *Menu) *menuState {
+	if m.states == nil {
+		m.states = make(map[*Menu]*menuState)
+	}
+	s, ok := m.states[menu]
+	if !ok {
+		s = &menuState{hotKeys: make(map[string]int)}
+		m.states[menu] = s
+	}
+	return s
+}
+
+// resetHotKeys empties the hotkeys before they are assigned again, keeping the map
+func (s *menuState) resetHotKeys() {
+	for k := range s.hotKeys {
+		delete(s.hotKeys, k)
+	}
+}
+
+// promptLines returns the prompt as drawn lines, built again only when its text changes
+func (s *menuState) promptLines(prompt string) []string {
+	if prompt == s.promptText && (s.prompt != nil || prompt == "") {
+		return s.prompt
+	}
+	s.promptText, s.prompt = prompt, nil
+	if prompt == "" {
+		return nil
+	}
+	prompt = strings.Replace(prompt, "\r\n", "\n", -1)
+	prompt = strings.Replace(prompt, "\n\r", "\n", -1)
+	for _, l := range strings.Split(prompt, "\n") {
+		s.prompt = append(s.prompt, " "+sanitize(l))
+	}
+	return s.prompt
+}
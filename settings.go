@@ -0,0 +1,173 @@
+package gomenutree
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SettingsMenu is a menu editing typed settings, one option per setting labelled with its current value: bool
+// settings toggle when chosen, int and string settings prompt for the new value and enum settings open a submenu of
+// choices; changes are written back through the settings' setters, and the error of a failed one is shown below the
+// prompt until the next change. Pass its Menu to AddSubMenu or NewMenuTree like any other menu
+type SettingsMenu struct {
+	*Menu
+	prompt string
+	err    error
+
+	// OnChange is called with the setting's name after a change was written back (e.g. to save the settings)
+	OnChange func(name string)
+
+	// Value formats an option's label from the setting's name and its current value, On and Off are the values shown
+	// for bool settings, Chosen and Unchosen mark the choices of an enum setting and Failed is shown below the prompt
+	// when a setter fails (%v is the error)
+	Value    string
+	On       string
+	Off      string
+	Chosen   string
+	Unchosen string
+	Failed   string
+}
+
+// NewSettingsMenu will create an empty settings menu (see AddBool, AddInt, AddString, AddEnum and AddStruct)
+func NewSettingsMenu(name string, prompt string) *SettingsMenu {
+	s := &SettingsMenu{
+		Menu:     NewMenu(name, "", nil),
+		prompt:   prompt,
+		Value:    "%s: %s",
+		On:       "on",
+		Off:      "off",
+		Chosen:   "(*)",
+		Unchosen: "( )",
+		Failed:   "Error: %v",
+	}
+	s.promptFunction = func() string {
+		if s.err == nil {
+			return s.prompt
+		}
+		return strings.TrimLeft(s.prompt+"\n"+fmt.Sprintf(s.Failed, s.err), "\n")
+	}
+	return s
+}
+
+// AddBool will add a setting toggled when chosen
+func (s *SettingsMenu) AddBool(name string, get func() bool, set func(bool) error) {
+	s.addOption(name, &option{function: func() {}, labelFunc: func() string {
+		if get() {
+			return fmt.Sprintf(s.Value, name, s.On)
+		}
+		return fmt.Sprintf(s.Value, name, s.Off)
+	}, jump: func() {
+		s.changed(name, set(!get()))
+	}})
+}
+
+// AddInt will add a setting prompting for a whole number when chosen (the current value is kept if nothing is typed)
+func (s *SettingsMenu) AddInt(name string, get func() int, set func(int) error) {
+	s.addEditor(name, func() string { return strconv.Itoa(get()) }, func(v int) error {
+		return s.changed(name, set(v))
+	})
+}
+
+// AddString will add a setting prompting for text when chosen (the current value is kept if nothing is typed)
+func (s *SettingsMenu) AddString(name string, get func() string, set func(string) error) {
+	s.addEditor(name, get, func(v string) error {
+		return s.changed(name, set(v))
+	})
+}
+
+// AddEnum will add a setting opening a submenu of the choices, the current one marked; choosing one sets it
+func (s *SettingsMenu) AddEnum(name string, choices []string, get func() string, set func(string) error) {
+	sub := NewMenu(name, "", func() string {
+		if s.err == nil {
+			return ""
+		}
+		return fmt.Sprintf(s.Failed, s.err)
+	})
+	for _, choice := range choices {
+		choice := choice
+		sub.addOption(choice, &option{function: func() {}, labelFunc: func() string {
+			if get() == choice {
+				return s.Chosen + " " + choice
+			}
+			return s.Unchosen + " " + choice
+		}, jump: func() {
+			s.changed(name, set(choice))
+		}})
+	}
+	s.addOption(name, &option{function: func() {}, subMenu: sub, labelFunc: func() string {
+		return fmt.Sprintf(s.Value, name, get())
+	}})
+}
+
+// AddStruct will add a setting for each field of the struct (v must be a pointer to it) tagged `setting:"name"` (the
+// field name if the tag is empty, "-" skips the field), written back to the field: bool fields, int kinds, and string
+// fields, an enum if also tagged with its comma separated choices (e.g. `choices:"debug,info,warn"`)
+func (s *SettingsMenu) AddStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gomenutree: expected a pointer to struct, got %T", v)
+	}
+	sv := rv.Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		sf := sv.Type().Field(i)
+		name, ok := sf.Tag.Lookup("setting")
+		if !ok || name == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return fmt.Errorf("gomenutree: tagged field %s.%s must be exported", sv.Type().Name(), sf.Name)
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fv := sv.Field(i)
+		switch fv.Kind() {
+		case reflect.Bool:
+			s.AddBool(name, fv.Bool, func(b bool) error {
+				fv.SetBool(b)
+				return nil
+			})
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s.AddInt(name, func() int { return int(fv.Int()) }, func(n int) error {
+				if fv.OverflowInt(int64(n)) {
+					return fmt.Errorf("gomenutree: %d is out of range for %s", n, name)
+				}
+				fv.SetInt(int64(n))
+				return nil
+			})
+		case reflect.String:
+			set := func(text string) error {
+				fv.SetString(text)
+				return nil
+			}
+			if choices, ok := sf.Tag.Lookup("choices"); ok {
+				s.AddEnum(name, strings.Split(choices, ","), fv.String, set)
+			} else {
+				s.AddString(name, fv.String, set)
+			}
+		default:
+			return fmt.Errorf("gomenutree: field %s.%s has unsupported setting type %s", sv.Type().Name(), sf.Name, fv.Type())
+		}
+	}
+	return nil
+}
+
+// addEditor adds a setting prompting for its new value (the handler takes a single typed parameter), returning to the
+// menu straight away
+func (s *SettingsMenu) addEditor(name string, current func() string, handler interface{}) {
+	none := PauseNone
+	s.addOption(name, &option{function: func() {}, pause: &none, labelFunc: func() string {
+		return fmt.Sprintf(s.Value, name, current())
+	}, args: &argSpec{handler: reflect.ValueOf(handler), args: []Arg{{Name: name, DefaultFunc: current}}}})
+}
+
+// changed records the outcome of writing back the setting, returning its error
+func (s *SettingsMenu) changed(name string, e error) error {
+	s.err = e
+	if e == nil && s.OnChange != nil {
+		s.OnChange(name)
+	}
+	return e
+}
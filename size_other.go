@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package gomenutree
+
+// terminalSize returns the dimensions of the local terminal, zero (unknown) where detection is not supported
+func terminalSize() (int, int) {
+	return 0, 0
+}
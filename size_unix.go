@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package gomenutree
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalSize returns the dimensions of the local terminal, zero if stdout is not a terminal
+func terminalSize() (int, int) {
+	ws, e := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if e != nil {
+		return 0, 0
+	}
+	return int(ws.Col), int(ws.Row)
+}
@@ -0,0 +1,133 @@
+package gomenutree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type (
+	// UIState is the part of the menu state kept between runs (see SetStateStore)
+	UIState struct {
+		Menu       string         `json:"menu,omitempty"`       //"/" separated path of the last visited menu
+		Selections map[string]int `json:"selections,omitempty"` //selection cursor by menu path
+		NoRedraw   bool           `json:"noRedraw,omitempty"`   //whether redraw was turned off (with the backtick)
+		Favorites  []string       `json:"favorites,omitempty"`  //favorite option paths
+		Usage      map[string]int `json:"usage,omitempty"`      //run count by option path
+		Recent     []string       `json:"recent,omitempty"`     //option paths run, most recent first
+	}
+
+	// StateStore loads and saves the UI state
+	StateStore interface {
+		Load() (UIState, error)
+		Save(state UIState) error
+	}
+
+	// stateFile is a StateStore keeping the state in a JSON file
+	stateFile string
+)
+
+// StateFile will return a StateStore keeping the state in a JSON file (a missing file means there is none yet)
+func StateFile(path string) StateStore {
+	return stateFile(path)
+}
+
+// Load implements StateStore
+func (f stateFile) Load() (UIState, error) {
+	var state UIState
+	b, e := os.ReadFile(string(f))
+	if errors.Is(e, os.ErrNotExist) {
+		return state, nil
+	} else if e != nil {
+		return state, e
+	}
+	return state, json.Unmarshal(b, &state)
+}
+
+// Save implements StateStore
+func (f stateFile) Save(state UIState) error {
+	b, e := json.MarshalIndent(state, "", "  ")
+	if e != nil {
+		return e
+	}
+	return os.WriteFile(string(f), b, 0o600)
+}
+
+// SetStateStore will load the UI state from the store, restored when Display (or Host) starts: the last visited menu
+// is reopened (or the deepest menu on its path that is visible and not protected, its loader running as it is first
+// drawn) with the cursors where they were left (see StickySelection), and the state is saved there whenever the menu
+// tree ends; usage counts and recent options are kept too, and favorites unless EnableFavorites was given a store of
+// its own (call EnableFavorites and ShowRecent first, so cursor positions match); nil stops saving
+func (m *MenuTree) SetStateStore(store StateStore) error {
+	m.stateStore = store
+	if store == nil {
+		return nil
+	}
+	state, e := store.Load()
+	if e != nil {
+		return fmt.Errorf("gomenutree: loading state: %w", e)
+	}
+	m.savedState = &state
+	return nil
+}
+
+// restoreSaved restores the state loaded by SetStateStore, once, when the menu tree starts being shown (so loaders of
+// the menu reopened run then, not while the tree is configured)
+func (m *MenuTree) restoreSaved() {
+	if m.savedState != nil {
+		m.restoreState(*m.savedState)
+		m.savedState = nil
+	}
+}
+
+// restoreState applies the loaded state, skipping menus that no longer exist; the last visited menu is only reopened as
+// far along its path as the menus are visible and not protected (see openPath)
+func (m *MenuTree) restoreState(state UIState) {
+	if state.NoRedraw {
+		m.Redraw = false
+	}
+	if m.favorites != nil && m.favorites.store == nil && len(state.Favorites) > 0 {
+		m.favorites.paths = state.Favorites
+		m.updateFavorites()
+	}
+	if len(state.Usage) > 0 || len(state.Recent) > 0 {
+		m.restoreUsage(state.Usage, state.Recent)
+	}
+	for path, selection := range state.Selections {
+		if menu, _, e := m.resolvePath(path); e == nil {
+			m.state(menu).selection = selection
+			m.state(menu).visited = true
+		}
+	}
+	if menu, parent, _ := m.openPath(state.Menu); menu != m.homeMenu {
+		m.currentMenu, m.previousMenu = menu, parent //entered (running its loader) as the menu is first drawn
+	}
+}
+
+// saveState saves the UI state to the store, if set
+func (m *MenuTree) saveState() {
+	if m.stateStore == nil {
+		return
+	}
+	state := UIState{
+		Menu:       strings.Join(m.menuPath(m.currentMenu), "/"),
+		Selections: make(map[string]int),
+		NoRedraw:   !m.Redraw,
+	}
+	for menu, s := range m.states {
+		if s.visited {
+			state.Selections[strings.Join(m.menuPath(menu), "/")] = s.selection
+		}
+	}
+	if m.favorites != nil && m.favorites.store == nil {
+		state.Favorites = m.Favorites()
+	}
+	if m.usageStats != nil {
+		state.Usage, state.Recent = m.usageStats.counts, m.usageStats.recent
+	}
+	if e := m.stateStore.Save(state); e != nil {
+		m.debug("state not saved", "error", e)
+	}
+}
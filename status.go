@@ -0,0 +1,48 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetStatus will set a static text, or a function to generate the text on render, for the status line shown below
+// the menu (e.g. connection state or the result of the last action); an empty status hides the line
+// status and statusFunction are mutually exclusive with statusFunction taking priority if not nil
+// safe to call from other goroutines: while waiting for input the status line is updated in place
+func (m *MenuTree) SetStatus(status string, statusFunction func() string) {
+	m.bgMu.Lock()
+	m.status = status
+	m.statusFunc = statusFunction
+	m.bgMu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.idle {
+		return
+	}
+	line := m.statusLine()
+	if (line != "") != m.statusShown {
+		m.render()
+	} else if line != "" {
+		fmt.Fprint(m.out, "\r\033[2K"+line)
+	}
+}
+
+// Status will return the current status line text
+func (m *MenuTree) Status() string {
+	return m.statusLine()
+}
+
+// statusLine builds the status line text, kept within the terminal width if known
+func (m *MenuTree) statusLine() string {
+	m.bgMu.Lock()
+	status, statusFunction := m.status, m.statusFunc
+	m.bgMu.Unlock()
+	if statusFunction != nil {
+		status = statusFunction()
+	}
+	status = strings.Replace(strings.Replace(status, "\r", "", -1), "\n", " ", -1)
+	if m.width > 0 && displayWidth(status) > m.width {
+		status = truncate(status, m.width)
+	}
+	return status
+}
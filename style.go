@@ -0,0 +1,151 @@
+package gomenutree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ttacon/chalk"
+)
+
+// Style holds the functions used to style each part of a menu (e.g. chalk.Red.Color or chalk.Bold.TextStyle);
+// nil fields inherit from the next level, option style > menu style > MenuTree.Theme
+type Style struct {
+	Title    func(string) string // menu name in the header
+	Heading  func(string) string // "Options:" and "SubMenus:" headings
+	Label    func(string) string // entries not under the selection cursor
+	Selected func(string) string // the entry under the selection cursor
+	HotKey   func(string) string // the hotkey character within an entry
+	Frame    func(string) string // the border
+	Disabled func(string) string // applied on top of Label/Selected for disabled options
+}
+
+// DefaultTheme will return the styling used when nothing else is set
+// (bold headings, italic selection, underlined hotkeys, dimmed disabled options)
+func DefaultTheme() Style {
+	return Style{
+		Title:    chalk.Bold.TextStyle,
+		Heading:  chalk.Bold.TextStyle,
+		Selected: chalk.Italic.TextStyle,
+		HotKey:   chalk.Underline.TextStyle,
+		Disabled: chalk.Dim.TextStyle,
+	}
+}
+
+// HighContrastTheme will return styling that does not rely on italics or underlines, which several terminals do not
+// show (bold yellow headings and hotkeys, reverse-video selection, dimmed disabled options)
+func HighContrastTheme() Style {
+	return Style{
+		Title:    compose(chalk.Bold.TextStyle, chalk.White.Color),
+		Heading:  compose(chalk.Bold.TextStyle, chalk.Yellow.Color),
+		Selected: chalk.Inverse.TextStyle,
+		HotKey:   compose(chalk.Bold.TextStyle, chalk.Yellow.Color),
+		Frame:    chalk.White.Color,
+		Disabled: chalk.Dim.TextStyle,
+	}
+}
+
+// ColorBlindTheme will return styling in a blue and yellow palette that stays distinct with red-green color
+// blindness (deuteranopia and protanopia), with a reverse-video selection instead of italics
+func ColorBlindTheme() Style {
+	return Style{
+		Title:    compose(chalk.Bold.TextStyle, chalk.Blue.Color),
+		Heading:  compose(chalk.Bold.TextStyle, chalk.Blue.Color),
+		Selected: chalk.Inverse.TextStyle,
+		HotKey:   compose(chalk.Bold.TextStyle, chalk.Yellow.Color),
+		Frame:    chalk.Blue.Color,
+		Disabled: chalk.Dim.TextStyle,
+	}
+}
+
+// themes are the predefined themes by name (see ThemeNamed)
+var themes = map[string]func() Style{
+	"default":       DefaultTheme,
+	"high-contrast": HighContrastTheme,
+	"color-blind":   ColorBlindTheme,
+}
+
+// ThemeNamed will return the predefined theme with the name (see ThemeNames), e.g. to pick one from a flag or a
+// settings file, or an error if there is none
+func ThemeNamed(name string) (Style, error) {
+	theme, ok := themes[name]
+	if !ok {
+		return Style{}, fmt.Errorf("gomenutree: theme %q not defined", name)
+	}
+	return theme(), nil
+}
+
+// ThemeNames will return the names of the predefined themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTheme will switch the tree's Theme to the predefined theme with the name (see ThemeNamed), redrawing the menu
+// right away while waiting for input
+func (m *MenuTree) SetTheme(name string) error {
+	theme, e := ThemeNamed(name)
+	if e != nil {
+		return e
+	}
+	m.Theme = theme
+	m.refresh()
+	return nil
+}
+
+// SetStyle will set the styling for this menu, overriding the tree's theme where fields are not nil
+func (m *Menu) SetStyle(style Style) {
+	m.style = style
+}
+
+// SetOptionStyle will set the styling for the named option (only Label, Selected, HotKey and Disabled apply),
+// overriding the menu style and theme where fields are not nil
+func (m *Menu) SetOptionStyle(name string, style Style) {
+	if o, ok := m.options[name]; ok {
+		o.style = style
+	}
+}
+
+// merge returns the style with nil fields taken from the fallback
+func (s Style) merge(fallback Style) Style {
+	if s.Title == nil {
+		s.Title = fallback.Title
+	}
+	if s.Heading == nil {
+		s.Heading = fallback.Heading
+	}
+	if s.Label == nil {
+		s.Label = fallback.Label
+	}
+	if s.Selected == nil {
+		s.Selected = fallback.Selected
+	}
+	if s.HotKey == nil {
+		s.HotKey = fallback.HotKey
+	}
+	if s.Frame == nil {
+		s.Frame = fallback.Frame
+	}
+	if s.Disabled == nil {
+		s.Disabled = fallback.Disabled
+	}
+	return s
+}
+
+// compose returns a function applying inner then outer (either may be nil)
+func compose(outer func(string) string, inner func(string) string) func(string) string {
+	return func(text string) string {
+		return apply(outer, apply(inner, text))
+	}
+}
+
+// apply styles the text with the function, or returns it unchanged if the function is nil
+func apply(styleFunction func(string) string, text string) string {
+	if styleFunction == nil {
+		return text
+	}
+	return styleFunction(text)
+}
@@ -0,0 +1,61 @@
+package gomenutree
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Suspend will hand the terminal back in its normal mode (cooked input, cursor shown, bracketed paste off), e.g.
+// before an option starts an editor, a shell or another full screen program; call Resume once it has finished
+func (m *MenuTree) Suspend() {
+	if m.suspended {
+		return
+	}
+	m.suspended = true
+	m.releaseTTY()
+	if m.ownsTerminal() {
+		fmt.Fprint(m.out, "\033[?25h\033[?2004l")
+	}
+}
+
+// Resume will take the terminal back after Suspend and redraw the menu in full below what the program left on the
+// screen (right away, or when the option that called it returns)
+func (m *MenuTree) Resume() {
+	if !m.suspended {
+		return
+	}
+	m.suspended = false
+	m.retake()
+}
+
+// retake hides the cursor and turns bracketed paste back on, redrawing the menu in full (unless an option is running)
+func (m *MenuTree) retake() {
+	if m.ownsTerminal() {
+		m.hideCursor()
+		fmt.Fprint(m.out, "\033[?2004h")
+	}
+	m.state(m.currentMenu).lastRenderLines = 0
+	if m.displaying && !m.inOption {
+		m.render()
+	}
+}
+
+// ExecCommand will run the command attached to the terminal (standard input, output and error), suspending the menu
+// while it runs (see Suspend), and return its error
+func (m *MenuTree) ExecCommand(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	m.Suspend()
+	defer m.Resume()
+	if e := cmd.Run(); e != nil {
+		return fmt.Errorf("gomenutree: running %s: %w", name, e)
+	}
+	return nil
+}
+
+// ownsTerminal reports whether the menu draws on the terminal itself, with its cursor hidden and bracketed paste on
+// (not in line mode, hosted, in an output region, Accessible or on a serial console)
+func (m *MenuTree) ownsTerminal() bool {
+	return m.displaying && !m.lineMode && !m.hosted && m.region == nil && !m.Accessible && !m.SerialConsole
+}
@@ -0,0 +1,67 @@
+package gomenutree
+
+import "strings"
+
+// tableGap separates the columns of table options
+const tableGap = "  "
+
+// AddTableOption will add an option shown as a row of cells (e.g. the name, status and IP of a server), aligned in
+// columns with the menu's other table options under the headings set with SetTableHeader; name stays the key for the
+// other option methods
+func (m *Menu) AddTableOption(name string, cells []string, function func()) {
+	m.addOption(name, &option{function: function, cells: append([]string(nil), cells...)})
+}
+
+// SetTableHeader will set the column headings drawn above the menu's table options (none removes them)
+func (m *Menu) SetTableHeader(headings ...string) {
+	m.tableHeader = append([]string(nil), headings...)
+}
+
+// text returns the option's label as matched by the search and hotkeys (table cells are joined by spaces)
+func (o *option) text(name string) string {
+	if o.cells != nil {
+		return strings.Join(o.cells, " ")
+	}
+	return evaluate(name, o.labelFunc)
+}
+
+// tableWidths returns the width of each column of the header and the visible table options of the current menu (nil
+// if it has none)
+func (m *MenuTree) tableWidths() []int {
+	var widths []int
+	measure := func(cells []string) {
+		for i, c := range cells {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := displayWidth(sanitize(c)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	for i, name := range m.currentMenu.optionsOrder {
+		if o := m.currentMenu.options[name]; o.cells != nil && (!o.hidden || m.revealed) && m.visible(m.currentMenu, i) {
+			measure(o.cells)
+		}
+	}
+	if widths != nil {
+		measure(m.currentMenu.tableHeader)
+	}
+	return widths
+}
+
+// alignCells pads each cell to its column width, the last one too so a selected row is highlighted across the table
+func alignCells(cells []string, widths []int) string {
+	var sb strings.Builder
+	for i, w := range widths {
+		if i > 0 {
+			sb.WriteString(tableGap)
+		}
+		c := ""
+		if i < len(cells) {
+			c = sanitize(cells[i])
+		}
+		sb.WriteString(c + strings.Repeat(" ", w-displayWidth(c)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,136 @@
+// Package teamenu hosts a gomenutree menu as a Bubble Tea component, so applications built on Bubble Tea can embed
+// a menu tree and get Bubble Tea's key, resize and mouse handling
+package teamenu
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mikefrom1974/gomenutree"
+)
+
+type (
+	// Model is a Bubble Tea model showing a hosted menu tree (see MenuTree.Host)
+	Model struct {
+		tree *gomenutree.MenuTree
+	}
+
+	// ExitMsg is sent once the menu tree ends, with how it ended
+	ExitMsg struct {
+		Reason gomenutree.ExitReason
+		Err    error
+	}
+
+	// tickMsg refreshes the view so background updates (async options, status lines) show up
+	tickMsg time.Time
+
+	// Backend shows menu trees as full screen Bubble Tea programs (see MenuTree.DisplayWith)
+	Backend struct{}
+
+	// program quits the Bubble Tea program when the menu tree ends, for Run
+	program struct {
+		Model
+	}
+)
+
+// New will host the menu tree and return a model for it, to embed in (or run as) a Bubble Tea program
+func New(tree *gomenutree.MenuTree) Model {
+	tree.Host()
+	return Model{tree: tree}
+}
+
+// Run will show the menu tree as a full screen Bubble Tea program until it ends, returning how it ended (like
+// tree.DisplayWith(Backend{}))
+func Run(tree *gomenutree.MenuTree) (gomenutree.ExitReason, error) {
+	return Backend{}.Run(tree)
+}
+
+// Run implements gomenutree.Backend
+func (Backend) Run(tree *gomenutree.MenuTree) (gomenutree.ExitReason, error) {
+	if _, e := tea.NewProgram(program{New(tree)}, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); e != nil {
+		return gomenutree.ExitError, e
+	}
+	return tree.ExitReason()
+}
+
+// Init implements tea.Model
+func (m Model) Init() tea.Cmd {
+	return tick()
+}
+
+// Update implements tea.Model, passing key presses and mouse wheel scrolls on to the menu tree
+// (and sending an ExitMsg once it ends)
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key := ""
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.tree.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		key = keyEvent(msg)
+	case tea.MouseMsg:
+		switch msg.Type {
+		case tea.MouseWheelUp:
+			key = "UP"
+		case tea.MouseWheelDown:
+			key = "DOWN"
+		}
+	case tickMsg:
+		return m, tick()
+	}
+	if key != "" && !m.tree.HandleKey(key) {
+		return m, func() tea.Msg {
+			reason, e := m.tree.ExitReason()
+			return ExitMsg{Reason: reason, Err: e}
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	return m.tree.View()
+}
+
+// Update implements tea.Model, quitting once the menu tree ends
+func (p program) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(ExitMsg); ok {
+		return p, tea.Quit
+	}
+	model, cmd := p.Model.Update(msg)
+	p.Model = model.(Model)
+	return p, cmd
+}
+
+// keyEvent translates a Bubble Tea key press into a menu tree key event
+func keyEvent(msg tea.KeyMsg) string {
+	switch msg.Type {
+	case tea.KeyUp:
+		return "UP"
+	case tea.KeyDown:
+		return "DOWN"
+	case tea.KeyLeft:
+		return "LEFT"
+	case tea.KeyRight:
+		return "RIGHT"
+	case tea.KeyEnter:
+		return "ENTER"
+	case tea.KeyEsc:
+		return "BACK"
+	case tea.KeyCtrlC:
+		return "INTERRUPT"
+	case tea.KeyRunes:
+		if s := string(msg.Runes); s == "x" {
+			return "EXIT"
+		} else if len(msg.Runes) == 1 {
+			return s
+		}
+	}
+	return ""
+}
+
+// tick schedules the next view refresh
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
@@ -0,0 +1,156 @@
+package gomenutree
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ansiPattern matches the terminal escape sequences used for styling and cursor movement, along with the OSC sequences
+// (e.g. hyperlinks and window titles) and two character escapes applications may put in labels and prompts
+var ansiPattern = regexp.MustCompile("\x1b(?:\\[[0-9:;<=>?]*[ -/]*[@-~]|\\][^\x07\x1b]*(?:\x07|\x1b\\\\)|[@-Z\\\\-_])")
+
+// crlfWriter translates "\n" into "\r\n", for raw-mode connections without a line discipline (e.g. SSH sessions)
+type crlfWriter struct {
+	w io.Writer
+}
+
+// Write implements io.Writer, reporting the length of the untranslated input
+func (c crlfWriter) Write(p []byte) (int, error) {
+	if _, e := c.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); e != nil {
+		return 0, e
+	}
+	return len(p), nil
+}
+
+// SetIO will read keystrokes from in and write all menu output to out instead of using the local terminal,
+// e.g. to serve menus over an SSH session (one MenuTree per session); the connection is expected to already be in
+// raw mode on the client side, so output newlines are translated to "\r\n" and typed lines are echoed by the menu
+// (a read error such as a closed connection ends Display with ExitError)
+func (m *MenuTree) SetIO(in io.Reader, out io.Writer) {
+	m.in, m.chunks = in, nil
+	m.out = crlfWriter{w: out}
+}
+
+// SetSize will set the terminal dimensions (e.g. from an SSH pty request or window change), used to keep the frame
+// within the terminal width (the local terminal is detected otherwise) and to account for wrapped lines when redrawing; zero means unknown
+func (m *MenuTree) SetSize(width int, height int) {
+	m.width = width
+	m.height = height
+	m.sizeSet = width > 0 || height > 0
+}
+
+// Writer will return the writer option functions should print to: the one the menu draws to, so they print to the
+// same session, or while their output is captured (pager, CopyKey, remote and web execution) the capture
+func (m *MenuTree) Writer() io.Writer {
+	m.outMu.Lock()
+	defer m.outMu.Unlock()
+	if m.optionOut != nil {
+		return m.optionOut
+	}
+	return m.out
+}
+
+// writerKey is the context key of the writer an option prints to (see WriterFrom)
+type writerKey struct{}
+
+// WriterFrom will return the writer a context option (see AddContextOption) should print to, from the context it was
+// given: the Writer of the tree or session running it. Options are shared by every session of a tree (see NewSession)
+// and stdout is not captured for sessions, so this is how an option prints to the session that chose it
+func WriterFrom(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(writerKey{}).(io.Writer); ok {
+		return w
+	}
+	return os.Stdout
+}
+
+// withOptionOut runs the function with Writer returning w, the previous writer being restored afterwards
+func (m *MenuTree) withOptionOut(w io.Writer, function func()) {
+	defer m.setOptionOut(w)()
+	function()
+}
+
+// setOptionOut makes Writer return w, returning the function restoring the previous writer
+func (m *MenuTree) setOptionOut(w io.Writer) func() {
+	m.outMu.Lock()
+	previous := m.optionOut
+	m.optionOut = w
+	m.outMu.Unlock()
+	return func() {
+		m.outMu.Lock()
+		m.optionOut = previous
+		m.outMu.Unlock()
+	}
+}
+
+// readLineFrom will read a line of text from the reader set with SetIO (starting with any bytes typed ahead), echoing
+// it (as "*" when masked) and handling backspace; it gives up with errIdle if nothing is typed within the timeout (0
+// waits for ever), and a read error ends the line (returned only if the line is empty)
+func (m *MenuTree) readLineFrom(masked bool, timeout time.Duration) (string, error) {
+	var line []rune
+	var pending []byte
+	for {
+		if len(m.keys.pending) == 0 {
+			chunk, e := m.inChunks().next(timeout)
+			if e != nil && len(line) > 0 {
+				return string(line), nil
+			} else if e != nil {
+				return "", e
+			}
+			m.keys.feed([]byte(chunk))
+			timeout = 0 //once typing has started, the rest of the line is waited for
+		}
+		b := m.keys.pending[:1]
+		m.keys.pending = m.keys.pending[1:]
+		switch b[0] {
+		case '\r', '\n':
+			if b[0] == '\r' && len(m.keys.pending) > 0 && m.keys.pending[0] == '\n' {
+				m.keys.pending = m.keys.pending[1:]
+			}
+			fmt.Fprintln(m.out)
+			return string(line), nil
+		case 127, 8:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				fmt.Fprint(m.out, "\b \b")
+			}
+		default:
+			pending = append(pending, b[0])
+			if utf8.FullRune(pending) {
+				r, _ := utf8.DecodeRune(pending)
+				pending = pending[:0]
+				line = append(line, r)
+				if masked {
+					fmt.Fprint(m.out, "*")
+				} else {
+					fmt.Fprint(m.out, string(r))
+				}
+			}
+		}
+	}
+}
+
+// rows returns the number of terminal lines the text moves down, counting lines wrapped by a known terminal width
+func (m *MenuTree) rows(text string) int {
+	count := strings.Count(text, "\n")
+	if m.width > 0 {
+		for text != "" {
+			l := text
+			if i := strings.IndexByte(text, '\n'); i >= 0 {
+				l, text = text[:i], text[i+1:]
+			} else {
+				text = ""
+			}
+			if vl := displayWidth(l); vl > m.width {
+				count += (vl - 1) / m.width
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,12 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package gomenutree
+
+import "golang.org/x/sys/unix"
+
+// termios requests reading and setting the terminal attributes
+const (
+	getTermios = unix.TIOCGETA
+	setTermios = unix.TIOCSETA
+)
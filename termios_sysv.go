@@ -0,0 +1,12 @@
+//go:build aix || linux || solaris || zos
+// +build aix linux solaris zos
+
+package gomenutree
+
+import "golang.org/x/sys/unix"
+
+// termios requests reading and setting the terminal attributes
+const (
+	getTermios = unix.TCGETS
+	setTermios = unix.TCSETS
+)
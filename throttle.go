@@ -0,0 +1,37 @@
+package gomenutree
+
+import "time"
+
+// navigationKey reports whether the key event only moves the selection cursor (so its render can be coalesced)
+func navigationKey(input string) bool {
+	switch input {
+	case "UP", "DOWN", "HOME", "END", "PGUP", "PGDN":
+		return true
+	}
+	return false
+}
+
+// handleInput handles the key event; navigation keys arriving faster than RenderInterval (e.g. a held arrow key, or
+// a slow link delivering them in bursts) are all applied with a single render of where the cursor ends up
+func (m *MenuTree) handleInput(input string) {
+	if m.RenderInterval <= 0 || !navigationKey(input) || m.inputFunc != nil || m.lineMode {
+		m.handleKey(input)
+		return
+	}
+	m.deferRender = true
+	for navigationKey(input) {
+		m.handleKey(input)
+		wait := m.RenderInterval - time.Since(m.lastRender)
+		if wait < time.Millisecond {
+			wait = time.Millisecond
+		}
+		input = m.getInputWithin(wait)
+	}
+	m.deferRender = false
+	if m.renderDue {
+		m.render()
+	}
+	if input != "IDLE" {
+		m.handleKey(input)
+	}
+}
@@ -0,0 +1,117 @@
+package gomenutree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// timeoutGrace is how long a timed out option is given to return once its context is cancelled, before the menu takes
+// over again
+const timeoutGrace = 500 * time.Millisecond
+
+// ErrTimedOut is the error (wrapped with the timeout) recorded for an option that ran longer than its timeout
+var ErrTimedOut = errors.New("gomenutree: option timed out")
+
+// AddContextOption will add an option whose function takes a context, cancelled once the option's timeout is exceeded
+// (see SetTimeout); its error is shown in the option output and passed to the audit sink (a nil function is added
+// without one, reported by Validate)
+func (m *Menu) AddContextOption(name string, function func(ctx context.Context) error) {
+	if function == nil {
+		m.addOption(name, &option{})
+		return
+	}
+	m.addOption(name, &option{contextFunction: function, function: func() {
+		_ = function(context.Background())
+	}})
+}
+
+// SetTimeout will limit how long the named option may run (0 removes the limit): once exceeded its context is
+// cancelled (commands are killed), it is reported as timed out in its output and the audit log, and the menu takes
+// over again; functions ignoring the context are left to finish in the background, what they write from then on to the
+// Writer they got while running being dropped (not what they print to stdout). Progress options are not limited
+func (m *Menu) SetTimeout(name string, timeout time.Duration) {
+	if o, ok := m.options[name]; ok {
+		o.timeout = timeout
+	}
+}
+
+// handler returns the option's function taking a context (plain functions ignore it)
+func (o *option) handler() func(ctx context.Context) error {
+	if o.contextFunction != nil {
+		return o.contextFunction
+	}
+	return func(context.Context) error {
+		o.function()
+		return nil
+	}
+}
+
+// abandonable passes writes on until the option writing them is abandoned after timing out, then drops them
+type abandonable struct {
+	w         io.Writer
+	abandoned int32 //set atomically
+}
+
+// Write implements io.Writer
+func (a *abandonable) Write(p []byte) (int, error) {
+	if atomic.LoadInt32(&a.abandoned) != 0 {
+		return len(p), nil
+	}
+	return a.w.Write(p)
+}
+
+// timed runs the function with a context cancelled after the option's timeout, returning an error wrapping
+// ErrTimedOut once the timeout is exceeded (after a short grace for the function to return); a panic in the function
+// is raised again on the caller's goroutine (so WithRecovery sees it), and while it runs Writer (and WriterFrom) gives
+// it its own abandonable writer, so a function left running does not write over the menu
+func (m *MenuTree) timed(name string, o *option, function func(ctx context.Context) error) error {
+	if o.timeout <= 0 {
+		return function(context.WithValue(context.Background(), writerKey{}, m.Writer()))
+	}
+	out := &abandonable{w: m.Writer()}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), writerKey{}, out), o.timeout)
+	defer cancel()
+	if o.asyncFunction == nil { //async options run in the background, while the menu keeps the writer
+		defer m.setOptionOut(out)()
+	}
+	done := make(chan func() error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if atomic.LoadInt32(&out.abandoned) != 0 {
+					m.debug("timed out option panicked", "option", name, "panic", fmt.Sprint(r))
+				}
+				done <- func() error { panic(r) }
+			}
+		}()
+		e := function(ctx)
+		done <- func() error { return e }
+	}()
+	select {
+	case result := <-done:
+		return result()
+	case <-ctx.Done():
+		m.debug("option timed out", "option", name, "timeout", o.timeout.String())
+		select {
+		case result := <-done:
+			_ = result() //still timed out, but a panic is raised
+		case <-time.After(timeoutGrace):
+		}
+		atomic.StoreInt32(&out.abandoned, 1)
+		return fmt.Errorf("%w after %s", ErrTimedOut, o.timeout)
+	}
+}
+
+// reportFailure shows in the option output that the option timed out, or the error of a context option
+func (m *MenuTree) reportFailure(name string, o *option, e error) {
+	switch {
+	case errors.Is(e, ErrTimedOut):
+		fmt.Fprintln(m.Writer(), fmt.Sprintf(m.Strings.TimedOut, name, o.timeout))
+	case e != nil && o.contextFunction != nil:
+		fmt.Fprintln(m.Writer(), fmt.Sprintf(m.Strings.OptionFailed, e))
+	}
+}
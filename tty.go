@@ -0,0 +1,100 @@
+package gomenutree
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ttyFile is the terminal keys are read from (even when stdin is redirected), along with the mode to restore
+type ttyFile struct {
+	*os.File
+	state   *term.State
+	wake    *os.File //readable once the process continues after being stopped (see watchJobControl)
+	unwatch func()
+}
+
+// errContinued is returned by waitKey when the process was stopped (e.g. with Ctrl+Z) and has continued since
+var errContinued = errors.New("gomenutree: process continued")
+
+// openTTY opens the controlling terminal (the console on Windows)
+func openTTY() (*ttyFile, error) {
+	f, e := os.OpenFile(ttyPath, os.O_RDWR, 0)
+	if e != nil {
+		return nil, e
+	}
+	return &ttyFile{File: f}, nil
+}
+
+// makeRaw puts the terminal in raw mode (no echo, line buffering or signal keys), remembering the mode to restore;
+// output is still translated ("\n" starting a new line), so the menu can be drawn while the terminal stays raw
+func (t *ttyFile) makeRaw() error {
+	if t.state != nil {
+		return nil
+	}
+	state, e := term.MakeRaw(int(t.Fd()))
+	if e != nil {
+		return e
+	}
+	t.state = state
+	return keepOutputProcessing(int(t.Fd()))
+}
+
+// restore puts the terminal back in the mode it had before makeRaw
+func (t *ttyFile) restore() {
+	if t.state != nil {
+		_ = term.Restore(int(t.Fd()), t.state)
+		t.state = nil
+	}
+}
+
+// close restores the terminal mode (if it was changed) and closes the terminal
+func (t *ttyFile) close() error {
+	if t.unwatch != nil {
+		t.unwatch()
+	}
+	t.restore()
+	return t.File.Close()
+}
+
+// rawTTY returns the terminal in raw mode for reading keys: while Display runs it is opened once and kept (see
+// releaseTTY), otherwise it is opened for this read only and done closes it
+func (m *MenuTree) rawTTY() (*ttyFile, func(), error) {
+	tty, done := m.tty, func() {}
+	if tty == nil {
+		var e error
+		if tty, e = openTTY(); e != nil {
+			return nil, nil, e
+		}
+		if m.displaying {
+			m.tty = tty
+			watchJobControl(tty)
+		} else {
+			done = func() {
+				_ = tty.close()
+			}
+		}
+	}
+	if e := tty.makeRaw(); e != nil {
+		done()
+		return nil, nil, e
+	}
+	return tty, done, nil
+}
+
+// releaseTTY hands the terminal back in its normal mode (e.g. before an option runs, or a line is read), keeping it
+// open for the next key
+func (m *MenuTree) releaseTTY() {
+	if m.tty != nil {
+		m.tty.restore()
+	}
+}
+
+// closeTTY restores and closes the terminal kept open while Display ran
+func (m *MenuTree) closeTTY() {
+	if m.tty != nil {
+		_ = m.tty.close()
+		m.tty = nil
+	}
+}
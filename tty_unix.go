@@ -0,0 +1,70 @@
+//go:build !windows
+// +build !windows
+
+package gomenutree
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// ttyPath is the controlling terminal
+const ttyPath = "/dev/tty"
+
+// waitInput waits up to timeout for input to read, reporting whether there is some
+func (t *ttyFile) waitInput(timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		wait := time.Until(deadline)
+		if wait < 0 {
+			wait = 0
+		}
+		fds := []unix.PollFd{{Fd: int32(t.Fd()), Events: unix.POLLIN}}
+		n, e := unix.Poll(fds, int(wait/time.Millisecond))
+		if e == unix.EINTR {
+			continue
+		}
+		return n > 0, e
+	}
+}
+
+// keepOutputProcessing turns output processing back on after term.MakeRaw, so "\n" still starts a new line
+func keepOutputProcessing(fd int) error {
+	termios, e := unix.IoctlGetTermios(fd, getTermios)
+	if e != nil {
+		return e
+	}
+	termios.Oflag |= unix.OPOST | unix.ONLCR
+	return unix.IoctlSetTermios(fd, setTermios, termios)
+}
+
+// waitKey waits up to timeout (for ever if 0) for a key, like waitInput, returning errContinued instead if the process
+// has continued after being stopped
+func (t *ttyFile) waitKey(timeout time.Duration) (bool, error) {
+	if t.wake == nil {
+		return t.waitInput(timeout)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		wait := -1
+		if timeout > 0 {
+			wait = int(time.Until(deadline) / time.Millisecond)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		fds := []unix.PollFd{{Fd: int32(t.Fd()), Events: unix.POLLIN}, {Fd: int32(t.wake.Fd()), Events: unix.POLLIN}}
+		n, e := unix.Poll(fds, wait)
+		if e == unix.EINTR {
+			continue
+		} else if e != nil {
+			return false, e
+		}
+		if fds[1].Revents&unix.POLLIN != 0 {
+			_, _ = t.wake.Read(make([]byte, 16))
+			return false, errContinued
+		}
+		return n > 0, nil
+	}
+}
@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package gomenutree
+
+import (
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ttyPath is the console input, read as virtual terminal sequences in raw mode
+const ttyPath = "CONIN$"
+
+// waitInput waits up to timeout for console input, reporting whether there is some
+func (t *ttyFile) waitInput(timeout time.Duration) (bool, error) {
+	event, e := windows.WaitForSingleObject(windows.Handle(t.Fd()), uint32(timeout/time.Millisecond))
+	if e != nil {
+		return false, e
+	}
+	return event == windows.WAIT_OBJECT_0, nil
+}
+
+// keepOutputProcessing does nothing: the console output mode is not changed by term.MakeRaw
+func keepOutputProcessing(int) error {
+	return nil
+}
+
+// waitKey waits up to timeout for a key, like waitInput (there is no job control to wake it on Windows)
+func (t *ttyFile) waitKey(timeout time.Duration) (bool, error) {
+	return t.waitInput(timeout)
+}
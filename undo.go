@@ -0,0 +1,100 @@
+package gomenutree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ttacon/chalk"
+)
+
+// lastAction is the option that last ran in the session
+type lastAction struct {
+	menu *Menu
+	name string
+	at   time.Time
+	undo func() error
+}
+
+// undoNoticeTTL is how long the outcome of an undo is shown
+const undoNoticeTTL = 5 * time.Second
+
+// SetUndo will register the function reverting what the named option does (e.g. turning a toggle back); once the
+// option has run without error, pressing UndoKey calls it after a y/n confirmation, until another option runs
+// (nil removes it)
+func (m *Menu) SetUndo(name string, undo func() error) {
+	if o, ok := m.options[name]; ok {
+		o.undo = undo
+	}
+}
+
+// LastAction will return the name of the option that last ran in the session and when it started ("" if none has)
+func (m *MenuTree) LastAction() (string, time.Time) {
+	m.bgMu.Lock()
+	defer m.bgMu.Unlock()
+	if m.last == nil {
+		return "", time.Time{}
+	}
+	return m.last.name, m.last.at
+}
+
+// trackAction records the option as the last action (replacing any undo), undoable if it has an undo function and
+// succeeded
+func (m *MenuTree) trackAction(menu *Menu, name string, start time.Time, e error) {
+	action := &lastAction{menu: menu, name: name, at: start}
+	if o, ok := menu.options[name]; ok && e == nil {
+		action.undo = o.undo
+	}
+	m.bgMu.Lock()
+	m.last = action
+	m.bgMu.Unlock()
+}
+
+// lastActionLine returns the dimmed "last: <option>, <time> ago" footer text (empty if ShowLastAction is off or no
+// option has run)
+func (m *MenuTree) lastActionLine() string {
+	m.bgMu.Lock()
+	last := m.last
+	m.bgMu.Unlock()
+	if !m.ShowLastAction || last == nil {
+		return ""
+	}
+	label := last.name
+	if o, ok := last.menu.options[last.name]; ok {
+		label = StripANSI(o.text(last.name))
+	}
+	ago := time.Since(last.at).Truncate(time.Second)
+	return chalk.Dim.TextStyle(fmt.Sprintf(m.Strings.LastAction, sanitize(label), ago))
+}
+
+// isUndoKey reports whether the (upper-cased) key event is the undo key
+func (m *MenuTree) isUndoKey(input string) bool {
+	return m.UndoKey != "" && input == strings.ToUpper(m.UndoKey)
+}
+
+// undoLast asks for confirmation and runs the last action's undo function, showing the outcome as a notification
+func (m *MenuTree) undoLast() {
+	m.bgMu.Lock()
+	last := m.last
+	m.bgMu.Unlock()
+	if last == nil || last.undo == nil {
+		m.Notify(m.Strings.NothingToUndo, LevelWarning, undoNoticeTTL)
+		m.render()
+		return
+	}
+	fmt.Fprintln(m.out, "\n"+fmt.Sprintf(m.Strings.ConfirmUndo, last.name))
+	m.state(m.currentMenu).lastRenderLines += 2
+	if answer := strings.ToUpper(m.getInput()); answer != "Y" {
+		m.render()
+		return
+	}
+	m.debug("undo", "menu", last.menu.name, "option", last.name)
+	m.releaseTTY()
+	e := m.audited(last.menu, fmt.Sprintf(m.Strings.UndoOption, last.name), last.undo)
+	if e != nil {
+		m.Notify(fmt.Sprintf(m.Strings.UndoFailed, last.name, e), LevelError, undoNoticeTTL)
+	} else {
+		m.Notify(fmt.Sprintf(m.Strings.Undone, last.name), LevelInfo, undoNoticeTTL)
+	}
+	m.render()
+}
@@ -0,0 +1,140 @@
+package gomenutree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IssueKind identifies a problem found by Validate
+type IssueKind int
+
+const (
+	IssueCycle       IssueKind = iota // a submenu leads back to one of the menus above it
+	IssueDuplicate                    // two entries of a menu share a name (only one can be reached by name)
+	IssueUnreachable                  // a menu has submenus registered but can not be reached from the home menu
+	IssueNilHandler                   // an option has no function to run (of any kind: plain, async, context or progress)
+	IssueNoHotKey                     // every character of an entry is taken as a hotkey by the entries above it
+	IssueEmptyMenu                    // a menu has neither options nor submenus
+)
+
+// String will return a short name for the issue kind
+func (k IssueKind) String() string {
+	switch k {
+	case IssueCycle:
+		return "cycle"
+	case IssueDuplicate:
+		return "duplicate"
+	case IssueUnreachable:
+		return "unreachable"
+	case IssueNilHandler:
+		return "nil handler"
+	case IssueNoHotKey:
+		return "no hotkey"
+	case IssueEmptyMenu:
+		return "empty menu"
+	}
+	return "unknown"
+}
+
+// Issue is a problem found by Validate
+type Issue struct {
+	Kind  IssueKind
+	Menu  string //"/" separated path of the menu from the home menu (or from the menu that can not be reached)
+	Entry string //the option or submenu concerned ("" for the menu itself)
+}
+
+// String will describe the issue, e.g. `duplicate: Main/Services "restart"`
+func (i Issue) String() string {
+	if i.Entry == "" {
+		return fmt.Sprintf("%s: %s", i.Kind, i.Menu)
+	}
+	return fmt.Sprintf("%s: %s %q", i.Kind, i.Menu, i.Entry)
+}
+
+// Validate will check the tree for problems before it is displayed (submenu cycles, duplicate entry names, menus that
+// can not be reached, options without a function, entries left without a hotkey and empty menus); none means the tree
+// is fine
+func (m *MenuTree) Validate() []Issue {
+	var issues []Issue
+	paths := map[*Menu]string{m.homeMenu: m.homeMenu.name}
+	var order []*Menu
+	onPath := make(map[*Menu]bool)
+	var walk func(menu *Menu)
+	walk = func(menu *Menu) {
+		order = append(order, menu)
+		onPath[menu] = true
+		for _, sm := range m.children(menu) {
+			if onPath[sm] {
+				issues = append(issues, Issue{Kind: IssueCycle, Menu: paths[menu], Entry: sm.name})
+				continue
+			}
+			if _, seen := paths[sm]; seen {
+				continue
+			}
+			paths[sm] = paths[menu] + "/" + sm.name
+			walk(sm)
+		}
+		onPath[menu] = false
+	}
+	walk(m.homeMenu)
+	var unreachable []*Menu
+	for parent := range m.subMenuMap {
+		if _, seen := paths[parent]; !seen {
+			unreachable = append(unreachable, parent)
+		}
+	}
+	sort.SliceStable(unreachable, func(i, j int) bool { return unreachable[i].name < unreachable[j].name })
+	for _, parent := range unreachable {
+		if _, seen := paths[parent]; !seen {
+			paths[parent] = parent.name
+			issues = append(issues, Issue{Kind: IssueUnreachable, Menu: parent.name})
+			walk(parent)
+		}
+	}
+	for _, menu := range order {
+		issues = append(issues, m.menuIssues(menu, paths[menu])...)
+	}
+	return issues
+}
+
+// menuIssues returns the problems with a single menu's entries
+func (m *MenuTree) menuIssues(menu *Menu, path string) []Issue {
+	var issues []Issue
+	names := make(map[string]bool)
+	state := &menuState{hotKeys: make(map[string]int)}
+	for i, name := range menu.optionsOrder {
+		if o := menu.options[name]; o.hotKey != "" {
+			state.hotKeys[strings.ToUpper(o.hotKey)] = i
+		}
+	}
+	entries := 0
+	for i, name := range menu.optionsOrder {
+		o := menu.options[name]
+		if o.separator {
+			continue
+		}
+		entries++
+		names[name] = true
+		if o.function == nil {
+			issues = append(issues, Issue{Kind: IssueNilHandler, Menu: path, Entry: name})
+		}
+		if o.hotKey == "" && !o.hidden && state.assignHotkey(o.text(name), i) == "" {
+			issues = append(issues, Issue{Kind: IssueNoHotKey, Menu: path, Entry: name})
+		}
+	}
+	for i, sm := range m.subMenuMap[menu] {
+		entries++
+		if names[sm.name] {
+			issues = append(issues, Issue{Kind: IssueDuplicate, Menu: path, Entry: sm.name})
+		}
+		names[sm.name] = true
+		if state.assignHotkey(sm.name, len(menu.optionsOrder)+i) == "" {
+			issues = append(issues, Issue{Kind: IssueNoHotKey, Menu: path, Entry: sm.name})
+		}
+	}
+	if entries == 0 {
+		issues = append(issues, Issue{Kind: IssueEmptyMenu, Menu: path})
+	}
+	return issues
+}
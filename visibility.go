@@ -0,0 +1,85 @@
+package gomenutree
+
+// Metadata describes an option or submenu entry to the visibility function (see MenuTree.SetVisibilityFunc)
+type Metadata struct {
+	Menu    string   //name of the menu the entry is in
+	Name    string   //option or submenu name
+	SubMenu bool     //whether the entry is a submenu
+	Roles   []string //roles set with SetOptionRoles or SetRoles (nil if none)
+}
+
+// SetOptionRoles will set the roles required to see the named option, checked by the visibility function
+func (m *Menu) SetOptionRoles(name string, roles ...string) {
+	if o, ok := m.options[name]; ok {
+		o.roles = roles
+	}
+}
+
+// SetRoles will set the roles required to see this menu as a submenu entry, checked by the visibility function
+func (m *Menu) SetRoles(roles ...string) {
+	m.roles = roles
+}
+
+// SetVisibilityFunc will set the function deciding which options and submenus are shown, so one tree can serve users
+// with different rights: an entry it rejects is not drawn, skipped by the cursor and can not be chosen, even by its
+// hotkey (nil shows every entry)
+func (m *MenuTree) SetVisibilityFunc(visible func(entry Metadata) bool) {
+	m.visibleFunc = visible
+}
+
+// HasRole will return a visibility function showing entries without roles, and entries requiring any of the
+// user's roles, e.g. SetVisibilityFunc(HasRole("operator"))
+func HasRole(userRoles ...string) func(entry Metadata) bool {
+	return func(entry Metadata) bool {
+		if len(entry.Roles) == 0 {
+			return true
+		}
+		for _, required := range entry.Roles {
+			for _, r := range userRoles {
+				if r == required {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// visible reports whether the visibility function shows the entry at index in the menu
+func (m *MenuTree) visible(menu *Menu, index int) bool {
+	if m.visibleFunc == nil {
+		return true
+	}
+	if index >= 0 && index < len(menu.optionsOrder) {
+		name := menu.optionsOrder[index]
+		entry := Metadata{Menu: menu.name, Name: name}
+		if o, ok := menu.options[name]; ok {
+			if o.separator {
+				return true
+			}
+			entry.Roles = o.roles
+			if o.subMenu != nil {
+				entry.SubMenu = true
+				if len(entry.Roles) == 0 {
+					entry.Roles = o.subMenu.roles
+				}
+			}
+		}
+		return m.visibleFunc(entry)
+	}
+	if smm := m.subMenuMap[menu]; index >= len(menu.optionsOrder) && index-len(menu.optionsOrder) < len(smm) {
+		sm := smm[index-len(menu.optionsOrder)]
+		return m.visibleFunc(Metadata{Menu: menu.name, Name: sm.name, SubMenu: true, Roles: sm.roles})
+	}
+	return true
+}
+
+// anyVisibleSubMenu reports whether the current menu has a submenu the visibility function shows
+func (m *MenuTree) anyVisibleSubMenu() bool {
+	for i := range m.subMenuMap[m.currentMenu] {
+		if m.visible(m.currentMenu, i+len(m.currentMenu.optionsOrder)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package gomenutree
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// webTokenTTL is how long the form of a rendered page may be posted
+const webTokenTTL = time.Hour
+
+// webMaxTokens is how many form tokens are kept at most, the oldest being dropped first (its page must be reloaded)
+const webMaxTokens = 1024
+
+type (
+	// webHandler serves a menu tree as HTML pages
+	webHandler struct {
+		m      *MenuTree
+		mu     sync.Mutex           //options run one at a time, their output is captured from Writer
+		tokens map[string]time.Time //form tokens of the pages rendered, with when they expire (webMaxTokens at most)
+	}
+
+	// webPage is what a menu page is rendered from
+	webPage struct {
+		Title       string
+		Environment string
+		Prompt      []string
+		Back        string
+		BackLabel   string
+		Action      string
+		Token       string
+		Entries     []webEntry
+		Ran         string
+		Output      []string
+		Error       string
+		Started     bool
+	}
+
+	// webEntry is a heading, an option button or a submenu link of a menu page
+	webEntry struct {
+		Heading  string
+		Name     string
+		Label    string
+		Link     string
+		Title    string
+		Status   string
+		Disabled bool
+	}
+)
+
+// webTemplate renders a menu page
+var webTemplate = template.Must(template.New("menu").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:40em;margin:2em auto;padding:0 1em}
+.env{background:#c00;color:#fff;padding:.1em .5em;border-radius:.3em;font-size:.6em;vertical-align:middle}
+form{margin:0}button,a.menu{display:block;width:100%;text-align:left;margin:.3em 0;padding:.5em;font:inherit;
+border:1px solid #888;border-radius:.3em;background:#f4f4f4;color:inherit;text-decoration:none;box-sizing:border-box}
+button:disabled{opacity:.5}.status{float:right;color:#666}pre{background:#222;color:#eee;padding:1em;overflow:auto}
+.error{color:#c00}
+</style></head><body>
+<h1>{{.Title}}{{if .Environment}} <span class="env">{{.Environment}}</span>{{end}}</h1>
+{{range .Prompt}}<p>{{.}}</p>{{end}}
+{{if .Ran}}<h2>{{.Ran}}</h2>{{if .Output}}<pre>{{range .Output}}{{.}}
+{{end}}</pre>{{end}}{{if .Error}}<p class="error">{{.Error}}</p>{{end}}{{end}}
+<form method="post" action="{{.Action}}"><input type="hidden" name="token" value="{{.Token}}">
+{{range .Entries}}{{if .Heading}}<h3>{{.Heading}}</h3>
+{{else if .Link}}<a class="menu" href="{{.Link}}" title="{{.Title}}">{{.Label}} &#9656;</a>
+{{else}}<button name="option" value="{{.Name}}" title="{{.Title}}"{{if .Disabled}} disabled{{end}}>{{.Label}}{{if .Status}}<span class="status">{{.Status}}</span>{{end}}</button>
+{{end}}{{end}}</form>
+{{if .Back}}<p><a href="{{.Back}}">&#8592; {{.BackLabel}}</a></p>{{end}}
+</body></html>
+`))
+
+// WebHandler will return an HTTP handler serving the menu tree as HTML pages: options are buttons running the same
+// handlers on the server (their output is shown on the page, async options are started in the background) and
+// submenus are links, the menu shown being chosen by the "path" query parameter (e.g. ?path=Settings/Network), so the
+// handler can be mounted anywhere. Options needing the terminal (arguments, passphrases, confirmation phrases,
+// progress) and protected menus are shown disabled. Each page's form carries a single use token, so options are only
+// run from a page the handler rendered within the last hour, and among the last 1024 rendered (a cross-site POST is
+// rejected). There is no authentication, wrap the handler
+// in the application's own. Options' output is captured from what they write to the tree's Writer, or to WriterFrom
+// for context options (stdout is not captured, so serving a NewSession of a tree displayed on the terminal is safe)
+func (m *MenuTree) WebHandler() http.Handler {
+	return &webHandler{m: m, tokens: make(map[string]time.Time)}
+}
+
+// ServeHTTP implements http.Handler
+func (h *webHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := r.URL.Query().Get("path")
+	menu, parent, re := h.m.remotePath(path)
+	if re != nil {
+		http.Error(w, re.message, re.status)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if r.Method == http.MethodPost && !h.redeem(r.PostFormValue("token")) {
+		http.Error(w, "invalid or expired form token, reload the page", http.StatusForbidden)
+		return
+	}
+	page := webPage{Title: menu.name, Environment: strings.ToUpper(h.m.Environment()), Action: webLink(path)}
+	var e error
+	if page.Token, e = h.token(); e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+		return
+	}
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		name := r.PostFormValue("option")
+		page.Ran = name
+		if o, re := h.m.detachable(menu, name); re != nil {
+			status, page.Error = re.status, re.message
+		} else {
+			h.m.debug("web execute", "menu", menu.name, "option", name)
+			result := h.m.runDetached(menu, name, o)
+			page.Output, page.Error, page.Started = result.Output, result.Error, result.Started
+			if page.Started {
+				page.Output = []string{fmt.Sprintf(h.m.Strings.Running, "0s")}
+			}
+		}
+	}
+	h.m.buildWebPage(&page, menu, path, parent)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = webTemplate.Execute(w, page)
+}
+
+// token returns a new form token for a rendered page, dropping expired ones and, once webMaxTokens are outstanding,
+// the oldest (mu must be held)
+func (h *webHandler) token() (string, error) {
+	now := time.Now()
+	oldest, oldestExpires := "", time.Time{}
+	for t, expires := range h.tokens {
+		if now.After(expires) {
+			delete(h.tokens, t)
+		} else if oldest == "" || expires.Before(oldestExpires) {
+			oldest, oldestExpires = t, expires
+		}
+	}
+	if len(h.tokens) >= webMaxTokens {
+		delete(h.tokens, oldest)
+	}
+	b := make([]byte, 16)
+	if _, e := rand.Read(b); e != nil {
+		return "", fmt.Errorf("gomenutree: generating form token: %w", e)
+	}
+	t := hex.EncodeToString(b)
+	h.tokens[t] = now.Add(webTokenTTL)
+	return t, nil
+}
+
+// redeem reports whether the form token was rendered by the handler and has not expired or been used (mu must be held)
+func (h *webHandler) redeem(token string) bool {
+	expires, ok := h.tokens[token]
+	delete(h.tokens, token)
+	return ok && time.Now().Before(expires)
+}
+
+// buildWebPage fills in the menu's prompt, entries and back link
+func (m *MenuTree) buildWebPage(page *webPage, menu *Menu, path string, parent *Menu) {
+	prompt := menu.prompt
+	if menu.promptFunction != nil {
+		prompt = menu.promptFunction()
+	}
+	for _, l := range strings.Split(strings.Replace(prompt, "\r", "", -1), "\n") {
+		if l = strings.TrimSpace(StripANSI(l)); l != "" {
+			page.Prompt = append(page.Prompt, l)
+		}
+	}
+	base := strings.Trim(path, "/")
+	if base != "" {
+		base += "/"
+	}
+	subMenu := func(sm *Menu) webEntry {
+		e := webEntry{Label: sm.name, Link: webLink(base + sm.name)}
+		if sm.gate != nil {
+			e.Link, e.Name, e.Disabled = "", sm.name, true
+		}
+		return e
+	}
+	if len(menu.optionsOrder) > 0 {
+		page.Entries = append(page.Entries, webEntry{Heading: m.Strings.Options})
+	}
+	for i, name := range menu.optionsOrder {
+		o := menu.options[name]
+		switch {
+		case (o.hidden && !m.revealed) || !m.visible(menu, i):
+		case o.separator:
+			page.Entries = append(page.Entries, webEntry{Heading: o.label})
+		case o.subMenu != nil:
+			page.Entries = append(page.Entries, subMenu(o.subMenu))
+		default:
+			page.Entries = append(page.Entries, webEntry{Name: name, Label: StripANSI(o.text(name)),
+				Title: o.description, Disabled: o.disabled || o.jump != nil || needsTerminal(o),
+				Status: strings.TrimSpace(m.asyncSuffix(o) + m.cooldownSuffix(o))})
+		}
+	}
+	var subMenus []webEntry
+	for i, sm := range m.subMenuMap[menu] {
+		if m.visible(menu, i+len(menu.optionsOrder)) {
+			subMenus = append(subMenus, subMenu(sm))
+		}
+	}
+	if len(subMenus) > 0 {
+		page.Entries = append(append(page.Entries, webEntry{Heading: m.Strings.SubMenus}), subMenus...)
+	}
+	if parent != nil {
+		parentPath := ""
+		if i := strings.LastIndex(strings.Trim(path, "/"), "/"); i >= 0 {
+			parentPath = strings.Trim(path, "/")[:i]
+		}
+		page.Back, page.BackLabel = webLink(parentPath), fmt.Sprintf(m.Strings.BackTo, parent.name)
+	}
+}
+
+// webLink returns the relative link to the menu at the path
+func webLink(path string) string {
+	if path == "" {
+		return "?"
+	}
+	return "?path=" + url.QueryEscape(path)
+}
@@ -0,0 +1,239 @@
+package gomenutree
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRunes holds the ranges of runes drawn two columns wide (CJK, fullwidth forms and emoji presentation)
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe4f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// runeWidth returns the number of columns the rune occupies on screen
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || (r >= 0xfe00 && r <= 0xfe0f):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// displayWidth returns the number of columns the text occupies on screen (escape sequences excluded)
+func displayWidth(text string) int {
+	width := 0
+	for i := 0; i < len(text); {
+		c := text[i]
+		if c == 0x1b {
+			if n := escapeLength(text[i:]); n > 0 {
+				i += n
+				continue
+			}
+		}
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != 0x7f {
+				width++
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		width += runeWidth(r)
+		i += size
+	}
+	return width
+}
+
+// escapeLength returns the length of the escape sequence starting the text (as matched by ansiPattern), 0 if it does
+// not start with one; it saves running the pattern over every line measured on every render
+func escapeLength(text string) int {
+	if len(text) < 2 || text[0] != 0x1b {
+		return 0
+	}
+	switch c := text[1]; {
+	case c == '[':
+		i := 2
+		for i < len(text) && (text[i] >= '0' && text[i] <= '?') {
+			i++
+		}
+		for i < len(text) && (text[i] >= ' ' && text[i] <= '/') {
+			i++
+		}
+		if i < len(text) && text[i] >= '@' && text[i] <= '~' {
+			return i + 1
+		}
+	case c == ']':
+		for i := 2; i < len(text); i++ {
+			if text[i] == 0x07 {
+				return i + 1
+			}
+			if text[i] == 0x1b {
+				if i+1 < len(text) && text[i+1] == '\\' {
+					return i + 2
+				}
+				break
+			}
+		}
+		return 2 // unterminated, just the two character escape
+	case c >= '@' && c <= 'Z', c >= '\\' && c <= '_':
+		return 2
+	}
+	return 0
+}
+
+// truncate cuts the text (with escape sequences removed) down to at most width columns
+func truncate(text string, width int) string {
+	var sb strings.Builder
+	used := 0
+	for _, r := range ansiPattern.ReplaceAllString(text, "") {
+		if used+runeWidth(r) > width {
+			break
+		}
+		used += runeWidth(r)
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// StripANSI will return the text with its terminal escape sequences (colors, cursor movement, hyperlinks) removed
+func StripANSI(text string) string {
+	return ansiPattern.ReplaceAllString(text, "")
+}
+
+// DisplayWidth will return the number of terminal columns the text occupies, ignoring escape sequences and counting
+// wide (e.g. CJK and emoji) characters as two, so colored text can be lined up with what the menu draws
+func DisplayWidth(text string) int {
+	return displayWidth(text)
+}
+
+// sanitize keeps the styling (and hyperlinks) of a label or prompt line but drops escape sequences that move the
+// cursor or clear the screen, and control characters, which would throw off the width math and the redraw
+func sanitize(text string) string {
+	if plainText(text) {
+		return text
+	}
+	plain := func(part string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case r == '\t':
+				return ' '
+			case r < 0x20 || r == 0x7f:
+				return -1
+			}
+			return r
+		}, part)
+	}
+	var sb strings.Builder
+	last := 0
+	for _, loc := range ansiPattern.FindAllStringIndex(text, -1) {
+		sb.WriteString(plain(text[last:loc[0]]))
+		if sequence := text[loc[0]:loc[1]]; strings.HasPrefix(sequence, "\x1b]") {
+			sb.WriteString(sequence)
+		} else {
+			sb.WriteString(keepStyle(sequence))
+		}
+		last = loc[1]
+	}
+	sb.WriteString(plain(text[last:]))
+	return sb.String()
+}
+
+// plainText reports whether the text has no escape sequences or control characters (so it needs no sanitizing)
+func plainText(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < 0x20 || text[i] == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleIndex returns the byte index of the first case insensitive occurrence of sub in text, skipping escape
+// sequences (-1 if there is none)
+func visibleIndex(text string, sub string) int {
+	if sub == "" {
+		return -1
+	}
+	for i := 0; i < len(text); {
+		if n := escapeLength(text[i:]); n > 0 {
+			i += n
+			continue
+		}
+		if hasPrefixFold(text[i:], sub) {
+			return i
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return -1
+}
+
+// hasPrefixFold reports whether the text starts with prefix, ignoring case
+func hasPrefixFold(text string, prefix string) bool {
+	for _, p := range prefix {
+		if text == "" {
+			return false
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		if r != p && !strings.EqualFold(string(r), string(p)) {
+			return false
+		}
+		text = text[size:]
+	}
+	return true
+}
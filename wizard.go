@@ -0,0 +1,175 @@
+package gomenutree
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrWizardCancelled is returned by RunWizard when the user exits before finishing every step
+var ErrWizardCancelled = errors.New("gomenutree: wizard cancelled")
+
+type (
+	// Wizard is a linear sequence of steps (choices, text inputs and confirmations) drawn like menus,
+	// navigated with Enter/right (next) and Esc/left (back), collecting one answer per step
+	Wizard struct {
+		name  string
+		steps []*wizardStep
+	}
+
+	// wizardStep holds a single step's configuration
+	wizardStep struct {
+		key          string
+		prompt       string
+		choices      []string
+		text         bool
+		defaultValue string
+		validate     func(string) error
+	}
+)
+
+// NewWizard will create an empty wizard (the name is shown in each step's header)
+func NewWizard(name string) *Wizard {
+	w := new(Wizard)
+	w.name = name
+	return w
+}
+
+// AddChoice will add a step answered by choosing one of the choices
+func (w *Wizard) AddChoice(key string, prompt string, choices ...string) {
+	w.steps = append(w.steps, &wizardStep{key: key, prompt: prompt, choices: choices})
+}
+
+// AddConfirm will add a yes/no step, answered with "yes" or "no"
+func (w *Wizard) AddConfirm(key string, prompt string) {
+	w.steps = append(w.steps, &wizardStep{key: key, prompt: prompt, choices: []string{"yes", "no"}})
+}
+
+// AddText will add a step answered by typing a value (starting from defaultValue), optionally validated
+// (a validation error is shown and the step repeated)
+func (w *Wizard) AddText(key string, prompt string, defaultValue string, validate func(string) error) {
+	w.steps = append(w.steps, &wizardStep{key: key, prompt: prompt, text: true, defaultValue: defaultValue, validate: validate})
+}
+
+// Run will run the wizard on the local terminal (see MenuTree.RunWizard)
+func (w *Wizard) Run() (map[string]string, error) {
+	return NewMenuTree(NewMenu(w.name, "", nil)).RunWizard(w)
+}
+
+// RunWizard will run the wizard using this tree's terminal, input and styling (e.g. from within an option function),
+// returning the answers by step key, or ErrWizardCancelled if the user exits (or the terminal error)
+func (m *MenuTree) RunWizard(w *Wizard) (map[string]string, error) {
+	currentMenu, previousMenu := m.currentMenu, m.previousMenu
+	menus := make([]*Menu, len(w.steps))
+	defer func() {
+		m.currentMenu, m.previousMenu = currentMenu, previousMenu
+		for _, menu := range menus {
+			delete(m.states, menu)
+		}
+		fmt.Fprintln(m.out)
+		if !m.displaying {
+			fmt.Fprintf(m.out, "\033[?25h")
+		}
+	}()
+	m.hideCursor()
+	answers := make(map[string]string)
+	for i := 0; i < len(w.steps); {
+		step := w.steps[i]
+		if menus[i] == nil {
+			menus[i] = NewMenu(fmt.Sprintf("%s (%d/%d)", w.name, i+1, len(w.steps)), step.prompt, nil)
+		}
+		menu := menus[i]
+		w.refreshStep(menu, step, answers, m.Strings.WizardValue)
+		m.currentMenu, m.previousMenu = menu, nil
+		if i > 0 {
+			m.previousMenu = menus[i-1]
+		}
+		m.state(menu).lastRenderLines = 0
+		m.initSelection()
+		m.render()
+		next := i
+		for next == i {
+			input := strings.ToUpper(m.getInput())
+			index := -1
+			switch input {
+			case "UP":
+				m.moveSelection(-1)
+				m.render()
+			case "DOWN":
+				m.moveSelection(1)
+				m.render()
+			case "ENTER", "RIGHT":
+				index = m.state(menu).selection
+			case "BACK", "LEFT":
+				if i > 0 {
+					next = i - 1
+				}
+			case "ERROR":
+				return nil, m.inputErr
+			case "EXIT", "INTERRUPT":
+				return nil, ErrWizardCancelled
+			default:
+				if hk, ok := m.state(menu).hotKeys[input]; ok {
+					m.state(menu).selection = hk
+					index = hk
+				}
+			}
+			if index < 0 || (!step.text && index >= len(step.choices)) { //e.g. a choice step added without choices
+				continue
+			}
+			if !step.text {
+				answers[step.key] = step.choices[index]
+				next = i + 1
+			} else if m.readWizardText(step, answers) {
+				next = i + 1
+			} else {
+				w.refreshStep(menu, step, answers, m.Strings.WizardValue)
+				m.render()
+			}
+		}
+		i = next
+	}
+	return answers, nil
+}
+
+// refreshStep (re)builds the step's menu entries from the current answers (text entries labelled with valueFormat)
+func (w *Wizard) refreshStep(menu *Menu, step *wizardStep, answers map[string]string, valueFormat string) {
+	menu.options = make(map[string]*option)
+	menu.optionsOrder = nil
+	if !step.text {
+		for _, c := range step.choices {
+			menu.AddOption(c, func() {})
+		}
+		return
+	}
+	value, ok := answers[step.key]
+	if !ok {
+		value = step.defaultValue
+	}
+	menu.AddOption(fmt.Sprintf(valueFormat, value), func() {})
+}
+
+// readWizardText reads and validates a text answer, reporting whether it was accepted
+func (m *MenuTree) readWizardText(step *wizardStep, answers map[string]string) bool {
+	value, ok := answers[step.key]
+	if !ok {
+		value = step.defaultValue
+	}
+	fmt.Fprintln(m.out)
+	line := m.ReadLine(fmt.Sprintf("%s [%s]: ", step.prompt, value))
+	m.state(m.currentMenu).lastRenderLines += 2
+	if line == "" {
+		line = value
+	}
+	if step.validate != nil {
+		if e := step.validate(line); e != nil {
+			fmt.Fprintln(m.out, fmt.Sprintf(m.Strings.InvalidValue, e))
+			fmt.Fprintln(m.out, m.continuePrompt())
+			m.state(m.currentMenu).lastRenderLines += 2
+			m.getInput()
+			return false
+		}
+	}
+	answers[step.key] = line
+	return true
+}